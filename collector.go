@@ -1,6 +1,7 @@
 package main
 
 import (
+	"slices"
 	"strings"
 
 	"github.com/modell-aachen/arista_exporter/collectors"
@@ -14,21 +15,55 @@ type Collector interface {
 	UpdateMetrics()
 }
 
-func getCollectorMap(enabled string) map[string]Collector {
+// Opt-in collectors are only enabled when listed explicitly. They run commands
+// that are only supported on some platforms or need features set up first.
+var optInCollectors = []string{"ptp", "mpls", "qos", "macsec", "dot1x"}
+
+func getCollectorMap(enabled string, target string) map[string]Collector {
 	allCollectors := map[string]Collector{
-		"version":     &collectors.VersionCollector{},
-		"power":       &collectors.PowerCollector{},
-		"interfaces":  &collectors.InterfacesCollector{},
-		"cooling":     &collectors.CoolingCollector{},
-		"temperature": &collectors.TemperatureCollector{},
-		"bgp":         &collectors.BgpCollector{},
+		"version":       &collectors.VersionCollector{},
+		"power":         &collectors.PowerCollector{},
+		"interfaces":    &collectors.InterfacesCollector{},
+		"cooling":       &collectors.CoolingCollector{},
+		"temperature":   &collectors.TemperatureCollector{},
+		"bgp":           &collectors.BgpCollector{},
+		"system":        &collectors.SystemCollector{},
+		"modules":       &collectors.ModulesCollector{},
+		"inventory":     &collectors.InventoryCollector{},
+		"stp":           &collectors.StpCollector{},
+		"errdisable":    &collectors.ErrdisableCollector{},
+		"ospf":          &collectors.OspfCollector{},
+		"isis":          &collectors.IsisCollector{},
+		"bfd":           &collectors.BfdCollector{},
+		"mac":           collectors.NewMacCollector(*macDetails),
+		"vlan":          &collectors.VlanCollector{},
+		"ntp":           &collectors.NtpCollector{},
+		"ptp":           &collectors.PtpCollector{},
+		"pim":           collectors.NewPimCollector(pimStates.Get(target), *pimNeighborExpiry),
+		"igmp":          &collectors.IgmpSnoopingCollector{},
+		"mpls":          &collectors.MplsCollector{},
+		"vrrp":          &collectors.VrrpCollector{},
+		"acl":           collectors.NewAclCollector(*aclInclude, *aclExclude, *aclRuleText, *aclTrafficPolicy),
+		"qos":           &collectors.QosCollector{},
+		"sflow":         &collectors.SflowCollector{},
+		"storm_control": &collectors.StormControlCollector{},
+		"port_security": &collectors.PortSecurityCollector{},
+		"macsec":        &collectors.MacsecCollector{},
+		"dot1x":         &collectors.Dot1xCollector{},
+		"mgmt":          &collectors.MgmtCollector{},
+		"event":         collectors.NewEventCollector(eventStates.Get(target)),
+		"config":        collectors.NewConfigCollector(configStates.Get(target)),
+		"reload":        &collectors.ReloadCollector{},
+		"storage":       &collectors.StorageCollector{},
 	}
 
 	collectorMap := make(map[string]Collector)
 
 	if enabled == "" {
 		for name, coll := range allCollectors {
-			collectorMap[name] = coll
+			if !slices.Contains(optInCollectors, name) {
+				collectorMap[name] = coll
+			}
 		}
 		return collectorMap
 	}
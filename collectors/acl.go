@@ -0,0 +1,134 @@
+package collectors
+
+import (
+	"regexp"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// AclCollector exports per-rule hit counters of IPv4 and IPv6 ACLs and traffic
+// policies. Since every rule becomes a series, the exported ACLs can be
+// limited with include and exclude patterns.
+type AclCollector struct {
+	AclList []AccessList `json:"aclList"`
+
+	ipv6Acls        Ipv6AccessLists
+	trafficPolicy   TrafficPolicyCounters
+	include         *regexp.Regexp
+	exclude         *regexp.Regexp
+	exportRuleText  bool
+	trafficPolicies bool
+
+	packetsGauge              *prometheus.GaugeVec
+	ruleInfo                  *prometheus.GaugeVec
+	trafficPolicyPacketsGauge *prometheus.GaugeVec
+	trafficPolicyBytesGauge   *prometheus.GaugeVec
+}
+
+type AccessList struct {
+	Name     string `json:"name"`
+	Sequence []struct {
+		SequenceNumber int    `json:"sequenceNumber"`
+		Text           string `json:"text"`
+		CounterData    struct {
+			PacketCount int `json:"packetCount"`
+		} `json:"counterData"`
+	} `json:"sequence"`
+}
+
+type Ipv6AccessLists struct {
+	AclList []AccessList `json:"aclList"`
+}
+
+func (a *Ipv6AccessLists) GetCmd() string {
+	return "show ipv6 access-lists"
+}
+
+type TrafficPolicyCounters struct {
+	TrafficPolicies map[string]struct {
+		Matches map[string]struct {
+			PacketCount int `json:"packetCount"`
+			ByteCount   int `json:"byteCount"`
+		} `json:"matches"`
+	} `json:"trafficPolicies"`
+}
+
+func (t *TrafficPolicyCounters) GetCmd() string {
+	return "show traffic-policy interface counters"
+}
+
+// NewAclCollector creates an AclCollector exporting the ACLs and traffic policies whose
+// name matches include but not exclude. With exportRuleText, the rule text is exported as well.
+// Traffic policies are only supported on some platforms, so they are only exported with trafficPolicies.
+func NewAclCollector(include *regexp.Regexp, exclude *regexp.Regexp, exportRuleText bool, trafficPolicies bool) *AclCollector {
+	return &AclCollector{include: include, exclude: exclude, exportRuleText: exportRuleText, trafficPolicies: trafficPolicies}
+}
+
+func (c *AclCollector) GetCmd() string {
+	return "show ip access-lists"
+}
+
+func (c *AclCollector) ExtraCmds() []Command {
+	if c.trafficPolicies {
+		return []Command{&c.ipv6Acls, &c.trafficPolicy}
+	}
+	return []Command{&c.ipv6Acls}
+}
+
+var aclOpts = MakeSubsystemOptsFactory("acl")
+
+func (c *AclCollector) Register(registry *prometheus.Registry) {
+	ruleLabels := []string{"acl", "af", "sequence"}
+	c.packetsGauge = prometheus.NewGaugeVec(aclOpts("packets", "Number of packets that matched the ACL rule"), ruleLabels)
+	c.ruleInfo = prometheus.NewGaugeVec(aclOpts("rule_info", "Text of the ACL rule"), append(ruleLabels, "rule"))
+
+	policyLabels := []string{"policy", "match"}
+	c.trafficPolicyPacketsGauge = prometheus.NewGaugeVec(aclOpts("traffic_policy_packets", "Number of packets that matched the traffic policy rule"), policyLabels)
+	c.trafficPolicyBytesGauge = prometheus.NewGaugeVec(aclOpts("traffic_policy_bytes", "Number of bytes that matched the traffic policy rule"), policyLabels)
+
+	registry.MustRegister(c.packetsGauge)
+	if c.exportRuleText {
+		registry.MustRegister(c.ruleInfo)
+	}
+	if c.trafficPolicies {
+		registry.MustRegister(c.trafficPolicyPacketsGauge, c.trafficPolicyBytesGauge)
+	}
+}
+
+func (c *AclCollector) UpdateMetrics() {
+	c.updateAcls("ipv4", c.AclList)
+	c.updateAcls("ipv6", c.ipv6Acls.AclList)
+
+	for name, policy := range c.trafficPolicy.TrafficPolicies {
+		if !c.exported(name) {
+			continue
+		}
+		for match, counters := range policy.Matches {
+			c.trafficPolicyPacketsGauge.WithLabelValues(name, match).Set(float64(counters.PacketCount))
+			c.trafficPolicyBytesGauge.WithLabelValues(name, match).Set(float64(counters.ByteCount))
+		}
+	}
+}
+
+func (c *AclCollector) updateAcls(af string, acls []AccessList) {
+	for _, acl := range acls {
+		if !c.exported(acl.Name) {
+			continue
+		}
+		for _, rule := range acl.Sequence {
+			seq := strconv.Itoa(rule.SequenceNumber)
+			c.packetsGauge.WithLabelValues(acl.Name, af, seq).Set(float64(rule.CounterData.PacketCount))
+			if c.exportRuleText {
+				c.ruleInfo.WithLabelValues(acl.Name, af, seq, rule.Text).Set(1)
+			}
+		}
+	}
+}
+
+func (c *AclCollector) exported(name string) bool {
+	if c.include != nil && !c.include.MatchString(name) {
+		return false
+	}
+	return c.exclude == nil || !c.exclude.MatchString(name)
+}
@@ -0,0 +1,105 @@
+package collectors
+
+import (
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type BfdCollector struct {
+	Vrfs map[string]struct {
+		Ipv4Neighbors map[string]BfdNeighbor `json:"ipv4Neighbors"`
+		Ipv6Neighbors map[string]BfdNeighbor `json:"ipv6Neighbors"`
+	} `json:"vrfs"`
+
+	sessionStateGauge    *prometheus.GaugeVec
+	upTransitionsGauge   *prometheus.GaugeVec
+	downTransitionsGauge *prometheus.GaugeVec
+	lastUpGauge          *prometheus.GaugeVec
+	lastDownGauge        *prometheus.GaugeVec
+	txIntervalGauge      *prometheus.GaugeVec
+	rxIntervalGauge      *prometheus.GaugeVec
+	detectMultGauge      *prometheus.GaugeVec
+	lastDiagInfo         *prometheus.GaugeVec
+}
+
+type BfdNeighbor struct {
+	PeerStats map[string]BfdSession `json:"peerStats"`
+}
+
+type BfdSession struct {
+	Status          string  `json:"status"`
+	LastUp          float64 `json:"lastUp"`
+	LastDown        float64 `json:"lastDown"`
+	LastDiag        string  `json:"lastDiag"`
+	PeerStatsDetail struct {
+		// Intervals are reported in microseconds
+		OperTxInterval  float64 `json:"operTxInterval"`
+		OperRxInterval  float64 `json:"operRxInterval"`
+		DetectMult      int     `json:"detectMult"`
+		UpTransitions   int     `json:"upTransitions"`
+		DownTransitions int     `json:"downTransitions"`
+	} `json:"peerStatsDetail"`
+}
+
+func (c *BfdCollector) GetCmd() string {
+	return "show bfd peers detail"
+}
+
+var bfdOpts = MakeSubsystemOptsFactory("bfd")
+
+func (c *BfdCollector) Register(registry *prometheus.Registry) {
+	// peer and vrf are named like in the bgp collector, so sessions can be joined with BGP peers
+	labels := []string{"peer", "vrf", "af", "interface"}
+
+	c.sessionStateGauge = prometheus.NewGaugeVec(bfdOpts("session_state", "BFD session state: 1=AdminDown, 2=Down, 3=Init, 4=Up"), labels)
+	c.upTransitionsGauge = prometheus.NewGaugeVec(bfdOpts("up_transitions", "Number of times the BFD session went up"), labels)
+	c.downTransitionsGauge = prometheus.NewGaugeVec(bfdOpts("down_transitions", "Number of times the BFD session went down"), labels)
+	c.lastUpGauge = prometheus.NewGaugeVec(bfdOpts("last_up_timestamp_seconds", "Unix timestamp at which the BFD session last went up"), labels)
+	c.lastDownGauge = prometheus.NewGaugeVec(bfdOpts("last_down_timestamp_seconds", "Unix timestamp at which the BFD session last went down"), labels)
+	c.txIntervalGauge = prometheus.NewGaugeVec(bfdOpts("tx_interval_seconds", "Operational transmit interval of the BFD session"), labels)
+	c.rxIntervalGauge = prometheus.NewGaugeVec(bfdOpts("rx_interval_seconds", "Operational receive interval of the BFD session"), labels)
+	c.detectMultGauge = prometheus.NewGaugeVec(bfdOpts("detect_multiplier", "Detect multiplier of the BFD session"), labels)
+	c.lastDiagInfo = prometheus.NewGaugeVec(bfdOpts("last_diagnostic", "Diagnostic reported when the BFD session last went down"), append(labels, "diagnostic"))
+
+	registry.MustRegister(c.sessionStateGauge, c.upTransitionsGauge, c.downTransitionsGauge, c.lastUpGauge, c.lastDownGauge)
+	registry.MustRegister(c.txIntervalGauge, c.rxIntervalGauge, c.detectMultGauge, c.lastDiagInfo)
+}
+
+func (c *BfdCollector) UpdateMetrics() {
+	for vrfName, vrf := range c.Vrfs {
+		c.updatePeers(vrfName, "ipv4", vrf.Ipv4Neighbors)
+		c.updatePeers(vrfName, "ipv6", vrf.Ipv6Neighbors)
+	}
+}
+
+func (c *BfdCollector) updatePeers(vrfName string, af string, neighbors map[string]BfdNeighbor) {
+	for addr, nbr := range neighbors {
+		for ifName, session := range nbr.PeerStats {
+			var state float64
+			switch strings.ToLower(session.Status) {
+			case "admindown":
+				state = 1
+			case "down":
+				state = 2
+			case "init":
+				state = 3
+			case "up":
+				state = 4
+			default:
+				state = 0
+			}
+			labels := []string{addr, vrfName, af, ifName}
+			detail := session.PeerStatsDetail
+			c.sessionStateGauge.WithLabelValues(labels...).Set(state)
+			c.upTransitionsGauge.WithLabelValues(labels...).Set(float64(detail.UpTransitions))
+			c.downTransitionsGauge.WithLabelValues(labels...).Set(float64(detail.DownTransitions))
+			c.lastUpGauge.WithLabelValues(labels...).Set(session.LastUp)
+			c.lastDownGauge.WithLabelValues(labels...).Set(session.LastDown)
+			c.txIntervalGauge.WithLabelValues(labels...).Set(detail.OperTxInterval / 1e6)
+			c.rxIntervalGauge.WithLabelValues(labels...).Set(detail.OperRxInterval / 1e6)
+			c.detectMultGauge.WithLabelValues(labels...).Set(float64(detail.DetectMult))
+			c.lastDiagInfo.WithLabelValues(append(labels, session.LastDiag)...).Set(1)
+		}
+	}
+}
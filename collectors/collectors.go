@@ -4,6 +4,45 @@ import "github.com/prometheus/client_golang/prometheus"
 
 const Namespace = "arista"
 
+// Command is an eAPI command whose response is decoded into the implementing type.
+type Command interface {
+	GetCmd() string
+}
+
+// MultiCommandCollector is implemented by collectors that need the JSON
+// response of further eAPI commands besides the one returned by GetCmd.
+type MultiCommandCollector interface {
+	ExtraCmds() []Command
+}
+
+// TextCommandCollector is implemented by collectors that need the plain text
+// output of eAPI commands which have no JSON representation.
+type TextCommandCollector interface {
+	TextCmds() []Command
+}
+
+// TextOnlyCollector is implemented by collectors whose own command has no JSON
+// representation either. The command is run with text encoding and its output
+// is decoded into the collector like a TextCommand.
+type TextOnlyCollector interface {
+	TextOnly()
+}
+
+// TextCommand holds the plain text output of an eAPI command run with text encoding.
+type TextCommand struct {
+	Output string `json:"output"`
+
+	cmd string
+}
+
+func NewTextCommand(cmd string) *TextCommand {
+	return &TextCommand{cmd: cmd}
+}
+
+func (t *TextCommand) GetCmd() string {
+	return t.cmd
+}
+
 func MakeSubsystemOptsFactory(Subsystem string) func(Name string, Help string) prometheus.GaugeOpts {
 	return func(Name string, Help string) prometheus.GaugeOpts {
 		return prometheus.GaugeOpts{
@@ -14,3 +53,21 @@ func MakeSubsystemOptsFactory(Subsystem string) func(Name string, Help string) p
 		}
 	}
 }
+
+// setStateSet records a state set: one series per possible state, where only the
+// current state is 1. The state label has to be the last label of the gauge.
+func setStateSet(gauge *prometheus.GaugeVec, states []string, current string, labels ...string) {
+	known := false
+	for _, state := range states {
+		value := 0.0
+		if state == current {
+			value = 1.0
+			known = true
+		}
+		gauge.WithLabelValues(append(labels, state)...).Set(value)
+	}
+	// Keep states missing from the list visible instead of dropping them
+	if !known && current != "" {
+		gauge.WithLabelValues(append(labels, current)...).Set(1)
+	}
+}
@@ -0,0 +1,109 @@
+package collectors
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// ConfigCollector detects changes of the running config by its hash. The hash
+// seen last and the time it changed are kept in a ConfigState between scrapes.
+// Changes made before the exporter started are only known from committed
+// configuration sessions.
+type ConfigCollector struct {
+	Sessions map[string]struct {
+		State         string  `json:"state"`
+		CompletedTime float64 `json:"completedTime"`
+	} `json:"sessions"`
+
+	state *ConfigState
+	// Time the config was requested, to tell stale scrapes from newer ones
+	fetched time.Time
+	diffs   *TextCommand
+	running *TextCommand
+
+	lastChangeGauge     *prometheus.GaugeVec
+	unsavedChangesGauge *prometheus.GaugeVec
+	hashInfo            *prometheus.GaugeVec
+	sessionsGauge       *prometheus.GaugeVec
+}
+
+// ConfigState holds the running config hash of a target between scrapes.
+type ConfigState struct {
+	mu         sync.Mutex
+	fetched    time.Time
+	hash       string
+	lastChange float64
+}
+
+func NewConfigCollector(state *ConfigState) *ConfigCollector {
+	return &ConfigCollector{state: state, fetched: time.Now()}
+}
+
+func (c *ConfigCollector) GetCmd() string {
+	return "show configuration sessions"
+}
+
+func (c *ConfigCollector) TextCmds() []Command {
+	c.diffs = NewTextCommand("show running-config diffs")
+	c.running = NewTextCommand("show running-config")
+	return []Command{c.diffs, c.running}
+}
+
+var configOpts = MakeSubsystemOptsFactory("config")
+
+func (c *ConfigCollector) Register(registry *prometheus.Registry) {
+	c.lastChangeGauge = prometheus.NewGaugeVec(configOpts("last_change_timestamp_seconds", "Unix timestamp of the last change of the running config, as far as seen since the exporter started or committed in a configuration session"), []string{})
+	c.unsavedChangesGauge = prometheus.NewGaugeVec(configOpts("unsaved_changes", "Whether the running config differs from the startup config (1 if true, 0 if false)"), []string{})
+	c.hashInfo = prometheus.NewGaugeVec(configOpts("hash_info", "SHA-256 hash of the running config"), []string{"sha256"})
+	c.sessionsGauge = prometheus.NewGaugeVec(configOpts("sessions", "Number of configuration sessions by state"), []string{"state"})
+
+	registry.MustRegister(c.lastChangeGauge, c.unsavedChangesGauge, c.hashInfo, c.sessionsGauge)
+}
+
+func (c *ConfigCollector) UpdateMetrics() {
+	sessions := make(map[string]int)
+	lastCommit := 0.0
+	for _, session := range c.Sessions {
+		sessions[session.State]++
+		lastCommit = max(lastCommit, session.CompletedTime)
+	}
+	for state, count := range sessions {
+		c.sessionsGauge.WithLabelValues(state).Set(float64(count))
+	}
+
+	if c.diffs != nil {
+		unsaved := 0.0
+		if strings.TrimSpace(c.diffs.Output) != "" {
+			unsaved = 1.0
+		}
+		c.unsavedChangesGauge.WithLabelValues().Set(unsaved)
+	}
+
+	if c.running == nil || c.running.Output == "" {
+		return
+	}
+	sum := sha256.Sum256([]byte(c.running.Output))
+	hash := hex.EncodeToString(sum[:])
+	c.hashInfo.WithLabelValues(hash).Set(1)
+
+	c.state.mu.Lock()
+	defer c.state.mu.Unlock()
+	// A scrape that requested the config before the last compared one could
+	// bring back an old hash, so only the newest config is compared
+	if !c.fetched.Before(c.state.fetched) {
+		if c.state.hash != "" && c.state.hash != hash {
+			c.state.lastChange = float64(c.fetched.Unix())
+		}
+		c.state.hash = hash
+		c.state.fetched = c.fetched
+	}
+	c.state.lastChange = max(c.state.lastChange, lastCommit)
+	if c.state.lastChange > 0 {
+		c.lastChangeGauge.WithLabelValues().Set(c.state.lastChange)
+	}
+}
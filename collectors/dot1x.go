@@ -0,0 +1,92 @@
+package collectors
+
+import (
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Dot1xCollector reports the 802.1X state of the ports. The RADIUS servers
+// behind it are reported by the mgmt collector along with the other AAA servers.
+type Dot1xCollector struct {
+	Interfaces map[string]struct {
+		Status string `json:"status"`
+	} `json:"interfaces"`
+
+	hosts      Dot1xHosts
+	statistics Dot1xStatistics
+
+	portAuthorizedGauge     *prometheus.GaugeVec
+	authenticatedHostsGauge *prometheus.GaugeVec
+	eapolRxGauge            *prometheus.GaugeVec
+	eapolTxGauge            *prometheus.GaugeVec
+}
+
+type Dot1xHosts struct {
+	IntfSupplicantsDict map[string]struct {
+		Supplicants map[string]struct {
+			Identity  string `json:"identity"`
+			AuthState string `json:"authState"`
+		} `json:"supplicants"`
+	} `json:"intfSupplicantsDict"`
+}
+
+func (h *Dot1xHosts) GetCmd() string {
+	return "show dot1x hosts"
+}
+
+type Dot1xStatistics struct {
+	Interfaces map[string]struct {
+		RxTotal int `json:"rxTotal"`
+		TxTotal int `json:"txTotal"`
+	} `json:"interfaces"`
+}
+
+func (s *Dot1xStatistics) GetCmd() string {
+	return "show dot1x statistics"
+}
+
+func (c *Dot1xCollector) GetCmd() string {
+	return "show dot1x all summary"
+}
+
+func (c *Dot1xCollector) ExtraCmds() []Command {
+	return []Command{&c.hosts, &c.statistics}
+}
+
+var dot1xOpts = MakeSubsystemOptsFactory("dot1x")
+
+func (c *Dot1xCollector) Register(registry *prometheus.Registry) {
+	ifLabels := []string{"interface"}
+	c.portAuthorizedGauge = prometheus.NewGaugeVec(dot1xOpts("port_authorized", "802.1X port state: 1 if authorized, 0 otherwise"), ifLabels)
+	c.authenticatedHostsGauge = prometheus.NewGaugeVec(dot1xOpts("authenticated_hosts", "Number of successfully authenticated hosts on the port"), ifLabels)
+	c.eapolRxGauge = prometheus.NewGaugeVec(dot1xOpts("eapol_frames_rx", "Number of EAPOL frames received on the port"), ifLabels)
+	c.eapolTxGauge = prometheus.NewGaugeVec(dot1xOpts("eapol_frames_tx", "Number of EAPOL frames sent on the port"), ifLabels)
+
+	registry.MustRegister(c.portAuthorizedGauge, c.authenticatedHostsGauge, c.eapolRxGauge, c.eapolTxGauge)
+}
+
+func (c *Dot1xCollector) UpdateMetrics() {
+	for ifName, iface := range c.Interfaces {
+		authorized := 0.0
+		if strings.EqualFold(iface.Status, "authorized") {
+			authorized = 1.0
+		}
+		c.portAuthorizedGauge.WithLabelValues(ifName).Set(authorized)
+	}
+
+	for ifName, iface := range c.hosts.IntfSupplicantsDict {
+		hosts := 0
+		for _, supplicant := range iface.Supplicants {
+			if strings.EqualFold(supplicant.AuthState, "success") {
+				hosts++
+			}
+		}
+		c.authenticatedHostsGauge.WithLabelValues(ifName).Set(float64(hosts))
+	}
+
+	for ifName, stats := range c.statistics.Interfaces {
+		c.eapolRxGauge.WithLabelValues(ifName).Set(float64(stats.RxTotal))
+		c.eapolTxGauge.WithLabelValues(ifName).Set(float64(stats.TxTotal))
+	}
+}
@@ -0,0 +1,86 @@
+package collectors
+
+import "github.com/prometheus/client_golang/prometheus"
+
+type ErrdisableCollector struct {
+	InterfaceStatuses map[string]ErrdisabledInterface `json:"interfaceStatuses"`
+
+	recovery ErrdisableRecovery
+
+	errdisabledGauge      *prometheus.GaugeVec
+	recoveryEnabledGauge  *prometheus.GaugeVec
+	recoveryIntervalGauge *prometheus.GaugeVec
+	recoveryTimeLeftGauge *prometheus.GaugeVec
+}
+
+type ErrdisabledInterface struct {
+	Description string   `json:"description"`
+	Status      string   `json:"status"`
+	Causes      []string `json:"causes"`
+}
+
+// ErrdisabledInterfaces lets other collectors request the errdisabled interfaces as an extra command.
+type ErrdisabledInterfaces struct {
+	InterfaceStatuses map[string]ErrdisabledInterface `json:"interfaceStatuses"`
+}
+
+func (e *ErrdisabledInterfaces) GetCmd() string {
+	return "show interfaces status errdisabled"
+}
+
+type ErrdisableRecovery struct {
+	Reasons map[string]struct {
+		Enabled  bool `json:"enabled"`
+		Interval int  `json:"interval"`
+	} `json:"reasons"`
+	Interfaces map[string]struct {
+		Reason   string  `json:"reason"`
+		TimeLeft float64 `json:"timeLeft"`
+	} `json:"interfaces"`
+}
+
+func (r *ErrdisableRecovery) GetCmd() string {
+	return "show errdisable recovery"
+}
+
+func (c *ErrdisableCollector) GetCmd() string {
+	return "show interfaces status errdisabled"
+}
+
+func (c *ErrdisableCollector) ExtraCmds() []Command {
+	return []Command{&c.recovery}
+}
+
+var errdisableOpts = MakeSubsystemOptsFactory("errdisable")
+
+func (c *ErrdisableCollector) Register(registry *prometheus.Registry) {
+	c.errdisabledGauge = prometheus.NewGaugeVec(errdisableOpts("interface", "Interface is errdisabled for the given cause (1 if true)"),
+		[]string{"interface", "description", "cause"})
+	c.recoveryEnabledGauge = prometheus.NewGaugeVec(errdisableOpts("recovery_enabled", "Whether automatic errdisable recovery is enabled for the cause (1 if true, 0 if false)"), []string{"cause"})
+	c.recoveryIntervalGauge = prometheus.NewGaugeVec(errdisableOpts("recovery_interval_seconds", "Errdisable recovery timer interval for the cause"), []string{"cause"})
+	c.recoveryTimeLeftGauge = prometheus.NewGaugeVec(errdisableOpts("recovery_time_left_seconds", "Seconds until the interface is re-enabled by errdisable recovery"),
+		[]string{"interface", "cause"})
+
+	registry.MustRegister(c.errdisabledGauge, c.recoveryEnabledGauge, c.recoveryIntervalGauge, c.recoveryTimeLeftGauge)
+}
+
+func (c *ErrdisableCollector) UpdateMetrics() {
+	for ifName, iface := range c.InterfaceStatuses {
+		for _, cause := range iface.Causes {
+			c.errdisabledGauge.WithLabelValues(ifName, iface.Description, cause).Set(1)
+		}
+	}
+
+	for cause, reason := range c.recovery.Reasons {
+		enabled := 0.0
+		if reason.Enabled {
+			enabled = 1.0
+		}
+		c.recoveryEnabledGauge.WithLabelValues(cause).Set(enabled)
+		c.recoveryIntervalGauge.WithLabelValues(cause).Set(float64(reason.Interval))
+	}
+
+	for ifName, iface := range c.recovery.Interfaces {
+		c.recoveryTimeLeftGauge.WithLabelValues(ifName, iface.Reason).Set(iface.TimeLeft)
+	}
+}
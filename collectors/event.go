@@ -0,0 +1,237 @@
+package collectors
+
+import (
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// EventCollector counts syslog messages in the logging buffer. As the buffer
+// is read in full on every scrape, the messages already counted are tracked
+// in an EventState that outlives the collector.
+type EventCollector struct {
+	Output string `json:"output"`
+
+	state *EventState
+	// Time the buffer was requested, to tell stale scrapes from newer ones
+	fetched time.Time
+	recent  *TextCommand
+
+	messagesGauge     *prometheus.GaugeVec
+	recentErrorsGauge *prometheus.GaugeVec
+}
+
+// EventState holds the message counts of a target between scrapes.
+type EventState struct {
+	mu       sync.Mutex
+	fetched  time.Time
+	cursor   eventCursor
+	messages map[eventKey]int
+}
+
+type eventKey struct {
+	facility string
+	severity string
+}
+
+type logMessage struct {
+	// Sequence number, or -1 unless logged with "logging format sequence-numbers"
+	seq      int64
+	time     time.Time
+	facility string
+	severity string
+}
+
+// eventCursor marks the newest message counted. Messages are identified by their
+// sequence number if the switch logs them, otherwise by their timestamp and how
+// many messages with that timestamp were counted.
+type eventCursor struct {
+	valid    bool
+	seq      int64
+	time     time.Time
+	sameTime int
+}
+
+func NewEventCollector(state *EventState) *EventCollector {
+	return &EventCollector{state: state, fetched: time.Now()}
+}
+
+func (c *EventCollector) GetCmd() string {
+	return "show logging"
+}
+
+func (c *EventCollector) TextOnly() {}
+
+func (c *EventCollector) TextCmds() []Command {
+	c.recent = NewTextCommand("show logging last 5 minutes")
+	return []Command{c.recent}
+}
+
+var eventOpts = MakeSubsystemOptsFactory("event")
+
+// Syslog messages carry their facility, severity and mnemonic, optionally
+// preceded by a sequence number, e.g.
+// "Oct 19 08:12:01 sw1 Ebra: 1042: %LINEPROTO-5-UPDOWN: Line protocol on Interface Ethernet1, changed state to up"
+var syslogMessage = regexp.MustCompile(`(?:(\d+): )?%([A-Z0-9_]+)-([0-7])-[A-Z0-9_]+:`)
+
+// Timestamps are logged either like "Oct 19 08:12:01" or, with high resolution, in RFC 3339
+var syslogTimestamp = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2}T\S+|[A-Z][a-z]{2} +\d{1,2} \d{2}:\d{2}:\d{2})`)
+
+var syslogSeverities = []string{"emergency", "alert", "critical", "error", "warning", "notice", "informational", "debugging"}
+
+func (c *EventCollector) Register(registry *prometheus.Registry) {
+	c.messagesGauge = prometheus.NewGaugeVec(eventOpts("messages", "Number of syslog messages logged since the exporter first read the log buffer of the target"),
+		[]string{"facility", "severity"})
+	c.recentErrorsGauge = prometheus.NewGaugeVec(eventOpts("recent_errors", "Number of syslog messages of severity error or worse logged in the last five minutes"),
+		[]string{"facility"})
+
+	registry.MustRegister(c.messagesGauge, c.recentErrorsGauge)
+}
+
+func (c *EventCollector) UpdateMetrics() {
+	now := time.Now()
+	messages := parseLogMessages(c.Output, now)
+
+	c.state.mu.Lock()
+	defer c.state.mu.Unlock()
+	if c.state.messages == nil {
+		c.state.messages = make(map[eventKey]int)
+	}
+
+	// A scrape that requested the buffer before the last counted one would count it twice
+	if !c.fetched.Before(c.state.fetched) {
+		var fresh []logMessage
+		fresh, c.state.cursor = c.state.cursor.advance(messages)
+		for _, msg := range fresh {
+			c.state.messages[eventKey{msg.facility, msg.severity}]++
+		}
+		c.state.fetched = c.fetched
+	}
+
+	for key, count := range c.state.messages {
+		c.messagesGauge.WithLabelValues(key.facility, key.severity).Set(float64(count))
+	}
+
+	if c.recent != nil {
+		errors := make(map[string]int)
+		for _, msg := range parseLogMessages(c.recent.Output, now) {
+			// Severities 0 to 3 are emergency to error
+			if slices.Index(syslogSeverities, msg.severity) <= 3 {
+				errors[msg.facility]++
+			}
+		}
+		for facility, count := range errors {
+			c.recentErrorsGauge.WithLabelValues(facility).Set(float64(count))
+		}
+	}
+}
+
+// advance returns the messages of the buffer that are newer than the cursor,
+// and the cursor for the newest message. All messages are new on the first
+// scrape, and after the sequence numbers or timestamps went back, which
+// happens when the switch reloads.
+func (cur eventCursor) advance(messages []logMessage) ([]logMessage, eventCursor) {
+	if len(messages) == 0 {
+		return nil, cur
+	}
+	last := messages[len(messages)-1]
+	next := eventCursor{valid: true, seq: last.seq, time: last.time}
+	for _, msg := range messages {
+		if msg.time.Equal(last.time) {
+			next.sameTime++
+		}
+	}
+	if !cur.valid {
+		return messages, next
+	}
+
+	useSeq := cur.seq >= 0
+	for _, msg := range messages {
+		if msg.seq < 0 {
+			useSeq = false
+		}
+	}
+
+	var fresh []logMessage
+	if useSeq {
+		if last.seq < cur.seq {
+			return messages, next
+		}
+		for _, msg := range messages {
+			if msg.seq > cur.seq {
+				fresh = append(fresh, msg)
+			}
+		}
+		return fresh, next
+	}
+
+	if last.time.Before(cur.time) {
+		return messages, next
+	}
+	// Messages of the cursor's second that already fell out of the buffer can't
+	// be told apart from new ones, so the cursor never counts fewer of them
+	if last.time.Equal(cur.time) {
+		next.sameTime = max(next.sameTime, cur.sameTime)
+	}
+	sameTime := 0
+	for _, msg := range messages {
+		switch {
+		case msg.time.After(cur.time):
+			fresh = append(fresh, msg)
+		case msg.time.Equal(cur.time):
+			sameTime++
+			if sameTime > cur.sameTime {
+				fresh = append(fresh, msg)
+			}
+		}
+	}
+	return fresh, next
+}
+
+// parseLogMessages returns the syslog messages of a log buffer, oldest first.
+func parseLogMessages(output string, now time.Time) []logMessage {
+	var messages []logMessage
+	for _, line := range strings.Split(output, "\n") {
+		match := syslogMessage.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		msg := logMessage{
+			seq:      -1,
+			facility: match[2],
+			severity: syslogSeverities[match[3][0]-'0'],
+		}
+		if match[1] != "" {
+			if seq, err := strconv.ParseInt(match[1], 10, 64); err == nil {
+				msg.seq = seq
+			}
+		}
+		if ts := syslogTimestamp.FindString(line); ts != "" {
+			msg.time = parseLogTimestamp(ts, now)
+		}
+		messages = append(messages, msg)
+	}
+	return messages
+}
+
+// parseLogTimestamp parses a syslog timestamp. Timestamps without a year are
+// placed in the year before now if they would otherwise lie in the future.
+func parseLogTimestamp(ts string, now time.Time) time.Time {
+	if t, err := time.Parse(time.RFC3339Nano, ts); err == nil {
+		return t
+	}
+	t, err := time.Parse("Jan 2 15:04:05", strings.Join(strings.Fields(ts), " "))
+	if err != nil {
+		return time.Time{}
+	}
+	t = t.AddDate(now.Year(), 0, 0)
+	if t.After(now.Add(24 * time.Hour)) {
+		t = t.AddDate(-1, 0, 0)
+	}
+	return t
+}
@@ -0,0 +1,141 @@
+package collectors
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var eventTestNow = time.Date(2024, time.October, 19, 12, 0, 0, 0, time.UTC)
+
+func TestParseLogMessages(t *testing.T) {
+	output := `Syslog logging: enabled
+    Buffer logging: level debugging
+
+Oct 19 08:12:01 sw1 Ebra: %LINEPROTO-5-UPDOWN: Line protocol on Interface Ethernet1, changed state to up
+Oct  9 08:12:02 sw1 Bgp: 1042: %BGP-3-NOTIFICATION: sent to neighbor 10.0.0.1 (VRF default AS 65001) 6/2 (Cease/administrative shutdown reset) 0 bytes
+2024-10-19T08:12:03.123456+00:00 sw1 Stp: %SPANTREE-6-INTERFACE_ADD: Interface Ethernet2 has been added to instance MST0
+Dec 31 23:59:59 sw1 Ebra: %LINK-3-UPDOWN: Interface Ethernet3, changed state to down
+    continuation line without a message`
+
+	messages := parseLogMessages(output, eventTestNow)
+	want := []logMessage{
+		{seq: -1, time: time.Date(2024, time.October, 19, 8, 12, 1, 0, time.UTC), facility: "LINEPROTO", severity: "notice"},
+		{seq: 1042, time: time.Date(2024, time.October, 9, 8, 12, 2, 0, time.UTC), facility: "BGP", severity: "error"},
+		{seq: -1, time: time.Date(2024, time.October, 19, 8, 12, 3, 123456000, time.UTC), facility: "SPANTREE", severity: "informational"},
+		// Not in the future, so logged last year
+		{seq: -1, time: time.Date(2023, time.December, 31, 23, 59, 59, 0, time.UTC), facility: "LINK", severity: "error"},
+	}
+	if len(messages) != len(want) {
+		t.Fatalf("parseLogMessages returned %d messages, want %d", len(messages), len(want))
+	}
+	for i, msg := range messages {
+		if msg.seq != want[i].seq || !msg.time.Equal(want[i].time) || msg.facility != want[i].facility || msg.severity != want[i].severity {
+			t.Errorf("message %d = %+v, want %+v", i, msg, want[i])
+		}
+	}
+}
+
+// logAt returns a message without sequence number logged the given seconds after eventTestNow.
+func logAt(second int, facility string) logMessage {
+	return logMessage{seq: -1, time: eventTestNow.Add(time.Duration(second) * time.Second), facility: facility, severity: "notice"}
+}
+
+// logSeq returns a message with sequence number, logged at eventTestNow.
+func logSeq(seq int64, facility string) logMessage {
+	return logMessage{seq: seq, time: eventTestNow, facility: facility, severity: "notice"}
+}
+
+func TestEventCursorAdvance(t *testing.T) {
+	tests := []struct {
+		name   string
+		scrape [][]logMessage
+		// Number of new messages found by the last scrape
+		want int
+	}{
+		{
+			name:   "first scrape",
+			scrape: [][]logMessage{{logAt(1, "A"), logAt(2, "B")}},
+			want:   2,
+		},
+		{
+			name:   "no new messages",
+			scrape: [][]logMessage{{logAt(1, "A"), logAt(2, "B")}, {logAt(1, "A"), logAt(2, "B")}},
+			want:   0,
+		},
+		{
+			name:   "empty buffer keeps cursor",
+			scrape: [][]logMessage{{logAt(1, "A")}, {}, {logAt(1, "A"), logAt(2, "B")}},
+			want:   1,
+		},
+		{
+			name:   "new messages",
+			scrape: [][]logMessage{{logAt(1, "A"), logAt(2, "B")}, {logAt(1, "A"), logAt(2, "B"), logAt(3, "C"), logAt(4, "D")}},
+			want:   2,
+		},
+		{
+			name:   "wrapped buffer",
+			scrape: [][]logMessage{{logAt(1, "A"), logAt(2, "B")}, {logAt(3, "C"), logAt(4, "D"), logAt(5, "E")}},
+			want:   3,
+		},
+		{
+			name:   "duplicates within a second",
+			scrape: [][]logMessage{{logAt(1, "A"), logAt(1, "A")}, {logAt(1, "A"), logAt(1, "A"), logAt(1, "A"), logAt(1, "A"), logAt(1, "A")}},
+			want:   3,
+		},
+		{
+			name:   "duplicates partly out of the buffer",
+			scrape: [][]logMessage{{logAt(1, "A"), logAt(1, "A"), logAt(1, "A")}, {logAt(1, "A"), logAt(2, "B")}},
+			want:   1,
+		},
+		{
+			name:   "timestamps went back",
+			scrape: [][]logMessage{{logAt(10, "A")}, {logAt(1, "B"), logAt(2, "C")}},
+			want:   2,
+		},
+		{
+			name:   "sequence numbers",
+			scrape: [][]logMessage{{logSeq(7, "A"), logSeq(8, "A")}, {logSeq(8, "A"), logSeq(9, "A"), logSeq(10, "A")}},
+			want:   2,
+		},
+		{
+			name:   "sequence numbers wrapped buffer",
+			scrape: [][]logMessage{{logSeq(7, "A"), logSeq(8, "A")}, {logSeq(12, "A"), logSeq(13, "A")}},
+			want:   2,
+		},
+		{
+			name:   "sequence numbers restarted",
+			scrape: [][]logMessage{{logSeq(7, "A"), logSeq(8, "A")}, {logSeq(1, "A"), logSeq(2, "A"), logSeq(3, "A")}},
+			want:   3,
+		},
+	}
+	for _, tt := range tests {
+		var cursor eventCursor
+		var fresh []logMessage
+		for _, messages := range tt.scrape {
+			fresh, cursor = cursor.advance(messages)
+		}
+		if len(fresh) != tt.want {
+			t.Errorf("%s: found %d new messages, want %d", tt.name, len(fresh), tt.want)
+		}
+	}
+}
+
+func TestEventCollectorSkipsStaleScrape(t *testing.T) {
+	state := &EventState{}
+	scrape := func(fetched time.Time, output string) {
+		c := &EventCollector{Output: output, state: state, fetched: fetched}
+		c.Register(prometheus.NewRegistry())
+		c.UpdateMetrics()
+	}
+
+	older := "Oct 19 08:12:01 sw1 Ebra: %LINEPROTO-5-UPDOWN: up\n"
+	newer := older + "Oct 19 08:12:02 sw1 Ebra: %LINEPROTO-5-UPDOWN: down\n"
+	scrape(eventTestNow.Add(time.Second), newer)
+	scrape(eventTestNow, older)
+
+	if got := state.messages[eventKey{"LINEPROTO", "notice"}]; got != 2 {
+		t.Errorf("counted %d messages, want 2", got)
+	}
+}
@@ -0,0 +1,28 @@
+package collectors
+
+import "github.com/prometheus/client_golang/prometheus"
+
+type IgmpSnoopingCollector struct {
+	Vlans map[string]struct {
+		GroupCount int `json:"groupCount"`
+	} `json:"vlans"`
+
+	groupsGauge *prometheus.GaugeVec
+}
+
+func (c *IgmpSnoopingCollector) GetCmd() string {
+	return "show ip igmp snooping groups count"
+}
+
+var igmpSnoopingOpts = MakeSubsystemOptsFactory("igmp_snooping")
+
+func (c *IgmpSnoopingCollector) Register(registry *prometheus.Registry) {
+	c.groupsGauge = prometheus.NewGaugeVec(igmpSnoopingOpts("groups", "Number of IGMP snooping group memberships in the VLAN"), []string{"vlan"})
+	registry.MustRegister(c.groupsGauge)
+}
+
+func (c *IgmpSnoopingCollector) UpdateMetrics() {
+	for vlan, counts := range c.Vlans {
+		c.groupsGauge.WithLabelValues(vlan).Set(float64(counts.GroupCount))
+	}
+}
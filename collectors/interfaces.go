@@ -11,6 +11,7 @@ type InterfacesCollector struct {
 
 	bandwidthGauge       *prometheus.GaugeVec
 	interfaceStatusGauge *prometheus.GaugeVec
+	operStatusGauge      *prometheus.GaugeVec
 
 	broadcastInGauge  *prometheus.GaugeVec
 	unicastInGauge    *prometheus.GaugeVec
@@ -103,6 +104,8 @@ func (c *InterfacesCollector) GetCmd() string {
 
 var interfacesOpts = MakeSubsystemOptsFactory("interface")
 
+var interfaceOperStatuses = []string{"connected", "notconnect", "disabled", "adminDown", "errdisabled", "inactive", "monitoring", "notPresent"}
+
 func (c *InterfacesCollector) Register(registry *prometheus.Registry) {
 	ifLabels := []string{"interface", "part", "description", "physical_address"}
 
@@ -159,6 +162,8 @@ func (c *InterfacesCollector) Register(registry *prometheus.Registry) {
 
 	c.interfaceStatusGauge = prometheus.NewGaugeVec(
 		interfacesOpts("status", "Interface status: 1 if connected, 0 otherwise"), ifLabels)
+	c.operStatusGauge = prometheus.NewGaugeVec(
+		interfacesOpts("oper_status", "Interface status as a state set over the EOS status strings"), append(ifLabels, "status"))
 
 	c.inputRuntFramesGauge = prometheus.NewGaugeVec(
 		interfacesOpts("input_runt_frames", "Input runt frames on the interface"), ifLabels)
@@ -185,6 +190,7 @@ func (c *InterfacesCollector) Register(registry *prometheus.Registry) {
 	)
 	registry.MustRegister(c.bandwidthGauge)
 	registry.MustRegister(c.interfaceStatusGauge)
+	registry.MustRegister(c.operStatusGauge)
 }
 
 func (c *InterfacesCollector) UpdateMetrics() {
@@ -243,5 +249,6 @@ func (c *InterfacesCollector) UpdateMetrics() {
 			status = 0
 		}
 		c.interfaceStatusGauge.WithLabelValues(ifName, ifPart, iface.Description, iface.PhysicalAddress).Set(status)
+		setStateSet(c.operStatusGauge, interfaceOperStatuses, iface.InterfaceStatus, ifName, ifPart, iface.Description, iface.PhysicalAddress)
 	}
 }
@@ -0,0 +1,102 @@
+package collectors
+
+import "github.com/prometheus/client_golang/prometheus"
+
+type InventoryCollector struct {
+	SystemInformation struct {
+		Name        string `json:"name"`
+		Description string `json:"description"`
+		HardwareRev string `json:"hardwareRev"`
+		SerialNum   string `json:"serialNum"`
+		MfgDate     string `json:"mfgDate"`
+	} `json:"systemInformation"`
+	PowerSupplySlots map[string]InventorySlot          `json:"powerSupplySlots"`
+	FanTraySlots     map[string]InventorySlot          `json:"fanTraySlots"`
+	CardSlots        map[string]InventorySlot          `json:"cardSlots"`
+	StorageDevices   map[string]InventoryStorageDevice `json:"storageDevices"`
+
+	PortCount           int `json:"portCount"`
+	SwitchedPortCount   int `json:"switchedPortCount"`
+	InternalPortCount   int `json:"internalPortCount"`
+	ManagementPortCount int `json:"managementPortCount"`
+	DataLinkPortCount   int `json:"dataLinkPortCount"`
+
+	systemInfo      *prometheus.GaugeVec
+	powerSupplyInfo *prometheus.GaugeVec
+	fanTrayInfo     *prometheus.GaugeVec
+	cardInfo        *prometheus.GaugeVec
+	storageInfo     *prometheus.GaugeVec
+	storageSize     *prometheus.GaugeVec
+	portCountGauge  *prometheus.GaugeVec
+}
+
+type InventorySlot struct {
+	Name        string `json:"name"`
+	ModelName   string `json:"modelName"`
+	SerialNum   string `json:"serialNum"`
+	HardwareRev string `json:"hardwareRev"`
+}
+
+type InventoryStorageDevice struct {
+	Model       string `json:"model"`
+	SerialNum   string `json:"serialNum"`
+	FirmwareRev string `json:"firmwareRev"`
+	MountPoint  string `json:"mountPoint"`
+	SizeGB      int    `json:"sizeGB"`
+}
+
+// model returns the slot's model, which EOS reports as name for power supplies and fan trays.
+func (s InventorySlot) model() string {
+	if s.ModelName != "" {
+		return s.ModelName
+	}
+	return s.Name
+}
+
+func (c *InventoryCollector) GetCmd() string {
+	return "show inventory"
+}
+
+var inventoryOpts = MakeSubsystemOptsFactory("inventory")
+
+func (c *InventoryCollector) Register(registry *prometheus.Registry) {
+	slotLabels := []string{"slot", "model", "serial", "hardware_revision"}
+
+	c.systemInfo = prometheus.NewGaugeVec(inventoryOpts("system_info", "Meta-info about the system chassis"),
+		[]string{"model", "description", "serial", "hardware_revision", "mfg_date"})
+	c.powerSupplyInfo = prometheus.NewGaugeVec(inventoryOpts("power_supply_info", "Meta-info about each power supply slot"), slotLabels)
+	c.fanTrayInfo = prometheus.NewGaugeVec(inventoryOpts("fan_tray_info", "Meta-info about each fan tray slot"), slotLabels)
+	c.cardInfo = prometheus.NewGaugeVec(inventoryOpts("card_info", "Meta-info about each linecard, supervisor and fabric slot"), slotLabels)
+	c.storageInfo = prometheus.NewGaugeVec(inventoryOpts("storage_info", "Meta-info about each storage device"),
+		[]string{"device", "model", "serial", "firmware_revision", "mount_point"})
+	c.storageSize = prometheus.NewGaugeVec(inventoryOpts("storage_size_bytes", "Size of the storage device in bytes"), []string{"device"})
+	c.portCountGauge = prometheus.NewGaugeVec(inventoryOpts("ports", "Number of ports by type"), []string{"type"})
+
+	registry.MustRegister(c.systemInfo, c.powerSupplyInfo, c.fanTrayInfo, c.cardInfo, c.storageInfo, c.storageSize, c.portCountGauge)
+}
+
+func (c *InventoryCollector) UpdateMetrics() {
+	sys := c.SystemInformation
+	c.systemInfo.WithLabelValues(sys.Name, sys.Description, sys.SerialNum, sys.HardwareRev, sys.MfgDate).Set(1)
+
+	for slot, psu := range c.PowerSupplySlots {
+		c.powerSupplyInfo.WithLabelValues(slot, psu.model(), psu.SerialNum, psu.HardwareRev).Set(1)
+	}
+	for slot, tray := range c.FanTraySlots {
+		c.fanTrayInfo.WithLabelValues(slot, tray.model(), tray.SerialNum, tray.HardwareRev).Set(1)
+	}
+	for slot, card := range c.CardSlots {
+		c.cardInfo.WithLabelValues(slot, card.model(), card.SerialNum, card.HardwareRev).Set(1)
+	}
+
+	for name, dev := range c.StorageDevices {
+		c.storageInfo.WithLabelValues(name, dev.Model, dev.SerialNum, dev.FirmwareRev, dev.MountPoint).Set(1)
+		c.storageSize.WithLabelValues(name).Set(float64(dev.SizeGB) * 1e9)
+	}
+
+	c.portCountGauge.WithLabelValues("total").Set(float64(c.PortCount))
+	c.portCountGauge.WithLabelValues("switched").Set(float64(c.SwitchedPortCount))
+	c.portCountGauge.WithLabelValues("internal").Set(float64(c.InternalPortCount))
+	c.portCountGauge.WithLabelValues("management").Set(float64(c.ManagementPortCount))
+	c.portCountGauge.WithLabelValues("data_link").Set(float64(c.DataLinkPortCount))
+}
@@ -0,0 +1,200 @@
+package collectors
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type IsisCollector struct {
+	Vrfs map[string]struct {
+		IsisInstances map[string]struct {
+			Neighbors map[string]struct {
+				Adjacencies []IsisAdjacency `json:"adjacencies"`
+			} `json:"neighbors"`
+		} `json:"isisInstances"`
+	} `json:"vrfs"`
+
+	database       IsisDatabase
+	spfLog         IsisSpfLog
+	segmentRouting IsisSegmentRouting
+	prefixSegments IsisPrefixSegments
+
+	adjacencyStateGauge   *prometheus.GaugeVec
+	lspCountGauge         *prometheus.GaugeVec
+	spfRunsGauge          *prometheus.GaugeVec
+	lastSpfAgeGauge       *prometheus.GaugeVec
+	lastSpfDurationGauge  *prometheus.GaugeVec
+	srgbBaseGauge         *prometheus.GaugeVec
+	srgbSizeGauge         *prometheus.GaugeVec
+	srPrefixSegmentsGauge *prometheus.GaugeVec
+}
+
+type IsisAdjacency struct {
+	Hostname      string `json:"hostname"`
+	State         string `json:"state"`
+	Level         string `json:"level"`
+	InterfaceName string `json:"interfaceName"`
+	RouterIdV4    string `json:"routerIdV4"`
+}
+
+// The database is requested without detail, since only the number of LSPs per level is exported.
+type IsisDatabase struct {
+	Vrfs map[string]struct {
+		IsisInstances map[string]struct {
+			Level map[string]struct {
+				Lsps map[string]interface{} `json:"lsps"`
+			} `json:"level"`
+		} `json:"isisInstances"`
+	} `json:"vrfs"`
+}
+
+func (d *IsisDatabase) GetCmd() string {
+	return "show isis database"
+}
+
+type IsisSpfLog struct {
+	Vrfs map[string]struct {
+		IsisInstances map[string]struct {
+			SpfLog []struct {
+				// Reported as a number or as a string like the adjacency level
+				Level     interface{} `json:"level"`
+				StartTime float64     `json:"startTime"`
+				// Duration of the SPF run in milliseconds
+				Duration float64 `json:"duration"`
+			} `json:"spfLog"`
+		} `json:"isisInstances"`
+	} `json:"vrfs"`
+}
+
+func (s *IsisSpfLog) GetCmd() string {
+	return "show isis spf log"
+}
+
+type IsisSegmentRouting struct {
+	Vrfs map[string]struct {
+		IsisInstances map[string]struct {
+			SrgbBase int `json:"srgbBase"`
+			SrgbSize int `json:"srgbSize"`
+		} `json:"isisInstances"`
+	} `json:"vrfs"`
+}
+
+func (s *IsisSegmentRouting) GetCmd() string {
+	return "show isis segment-routing"
+}
+
+type IsisPrefixSegments struct {
+	Vrfs map[string]struct {
+		IsisInstances map[string]struct {
+			PrefixSegments []interface{} `json:"prefixSegments"`
+		} `json:"isisInstances"`
+	} `json:"vrfs"`
+}
+
+func (s *IsisPrefixSegments) GetCmd() string {
+	return "show isis segment-routing prefix-segments"
+}
+
+func (c *IsisCollector) GetCmd() string {
+	return "show isis neighbors"
+}
+
+func (c *IsisCollector) ExtraCmds() []Command {
+	return []Command{&c.database, &c.spfLog, &c.segmentRouting, &c.prefixSegments}
+}
+
+var isisOpts = MakeSubsystemOptsFactory("isis")
+
+func (c *IsisCollector) Register(registry *prometheus.Registry) {
+	instanceLabels := []string{"vrf", "instance"}
+	levelLabels := []string{"vrf", "instance", "level"}
+
+	c.adjacencyStateGauge = prometheus.NewGaugeVec(isisOpts("adjacency_state", "IS-IS adjacency state: 1=Down, 2=Init, 3=Up"),
+		[]string{"vrf", "instance", "neighbor", "hostname", "interface", "level"})
+	c.lspCountGauge = prometheus.NewGaugeVec(isisOpts("lsp_count", "Number of LSPs in the link state database"), levelLabels)
+	c.spfRunsGauge = prometheus.NewGaugeVec(isisOpts("spf_log_runs", "Number of SPF runs recorded in the SPF log"), levelLabels)
+	c.lastSpfAgeGauge = prometheus.NewGaugeVec(isisOpts("last_spf_age_seconds", "Seconds since the last SPF run"), levelLabels)
+	c.lastSpfDurationGauge = prometheus.NewGaugeVec(isisOpts("last_spf_duration_seconds", "Duration of the last SPF run"), levelLabels)
+	c.srgbBaseGauge = prometheus.NewGaugeVec(isisOpts("sr_global_block_base", "First label of the segment routing global block"), instanceLabels)
+	c.srgbSizeGauge = prometheus.NewGaugeVec(isisOpts("sr_global_block_size", "Number of labels in the segment routing global block"), instanceLabels)
+	c.srPrefixSegmentsGauge = prometheus.NewGaugeVec(isisOpts("sr_prefix_segments", "Number of prefix segments allocated from the segment routing global block"), instanceLabels)
+
+	registry.MustRegister(c.adjacencyStateGauge, c.lspCountGauge, c.spfRunsGauge, c.lastSpfAgeGauge, c.lastSpfDurationGauge)
+	registry.MustRegister(c.srgbBaseGauge, c.srgbSizeGauge, c.srPrefixSegmentsGauge)
+}
+
+func (c *IsisCollector) UpdateMetrics() {
+	for vrfName, vrf := range c.Vrfs {
+		for instName, inst := range vrf.IsisInstances {
+			for systemID, nbr := range inst.Neighbors {
+				for _, adj := range nbr.Adjacencies {
+					var state float64
+					switch strings.ToLower(adj.State) {
+					case "down":
+						state = 1
+					case "init":
+						state = 2
+					case "up":
+						state = 3
+					default:
+						state = 0
+					}
+					c.adjacencyStateGauge.WithLabelValues(vrfName, instName, systemID, adj.Hostname, adj.InterfaceName, isisLevel(adj.Level)).Set(state)
+				}
+			}
+		}
+	}
+
+	for vrfName, vrf := range c.database.Vrfs {
+		for instName, inst := range vrf.IsisInstances {
+			for level, db := range inst.Level {
+				c.lspCountGauge.WithLabelValues(vrfName, instName, isisLevel(level)).Set(float64(len(db.Lsps)))
+			}
+		}
+	}
+
+	now := float64(time.Now().Unix())
+	for vrfName, vrf := range c.spfLog.Vrfs {
+		for instName, inst := range vrf.IsisInstances {
+			runs := make(map[string]int)
+			lastStart := make(map[string]float64)
+			lastDuration := make(map[string]float64)
+			for _, run := range inst.SpfLog {
+				level := isisLevel(fmt.Sprint(run.Level))
+				runs[level]++
+				if run.StartTime > lastStart[level] {
+					lastStart[level] = run.StartTime
+					lastDuration[level] = run.Duration / 1000
+				}
+			}
+			for level, count := range runs {
+				c.spfRunsGauge.WithLabelValues(vrfName, instName, level).Set(float64(count))
+				if lastStart[level] > 0 {
+					c.lastSpfAgeGauge.WithLabelValues(vrfName, instName, level).Set(now - lastStart[level])
+					c.lastSpfDurationGauge.WithLabelValues(vrfName, instName, level).Set(lastDuration[level])
+				}
+			}
+		}
+	}
+
+	for vrfName, vrf := range c.segmentRouting.Vrfs {
+		for instName, inst := range vrf.IsisInstances {
+			c.srgbBaseGauge.WithLabelValues(vrfName, instName).Set(float64(inst.SrgbBase))
+			c.srgbSizeGauge.WithLabelValues(vrfName, instName).Set(float64(inst.SrgbSize))
+		}
+	}
+
+	for vrfName, vrf := range c.prefixSegments.Vrfs {
+		for instName, inst := range vrf.IsisInstances {
+			c.srPrefixSegmentsGauge.WithLabelValues(vrfName, instName).Set(float64(len(inst.PrefixSegments)))
+		}
+	}
+}
+
+// isisLevel normalizes "level-2" and "2" to the same label value.
+func isisLevel(level string) string {
+	return strings.TrimPrefix(strings.ToLower(level), "level-")
+}
@@ -0,0 +1,144 @@
+package collectors
+
+import (
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// MacCollector reports the size of the MAC address table and of the ARP and
+// IPv6 neighbor tables. Only counts are exported, never the entries themselves.
+type MacCollector struct {
+	// Entry counts keyed by entry type, per VLAN
+	VlanCounts map[string]map[string]interface{} `json:"vlanCounts"`
+
+	details bool
+
+	macTable     MacAddressTable
+	arpTable     ArpTable
+	ipv6Neighbor Ipv6NeighborTable
+
+	macEntriesGauge  *prometheus.GaugeVec
+	macMovesGauge    *prometheus.GaugeVec
+	macLastMoveGauge *prometheus.GaugeVec
+	arpEntriesGauge  *prometheus.GaugeVec
+	ndEntriesGauge   *prometheus.GaugeVec
+}
+
+type MacAddressTable struct {
+	UnicastTable struct {
+		TableEntries []struct {
+			VlanID    int     `json:"vlanId"`
+			EntryType string  `json:"entryType"`
+			Moves     int     `json:"moves"`
+			LastMove  float64 `json:"lastMove"`
+		} `json:"tableEntries"`
+	} `json:"unicastTable"`
+}
+
+func (m *MacAddressTable) GetCmd() string {
+	return "show mac address-table"
+}
+
+type ArpTable struct {
+	Vrfs map[string]struct {
+		IpV4Neighbors []struct {
+			Interface string `json:"interface"`
+		} `json:"ipV4Neighbors"`
+	} `json:"vrfs"`
+}
+
+func (a *ArpTable) GetCmd() string {
+	return "show ip arp vrf all"
+}
+
+type Ipv6NeighborTable struct {
+	Vrfs map[string]struct {
+		IpV6Neighbors []struct {
+			Interface string `json:"interface"`
+		} `json:"ipV6Neighbors"`
+	} `json:"vrfs"`
+}
+
+func (n *Ipv6NeighborTable) GetCmd() string {
+	return "show ipv6 neighbors vrf all"
+}
+
+// NewMacCollector creates a MacCollector. MAC moves and the ARP and IPv6 neighbor
+// counts can only be derived from the full tables, so they are exported with details only.
+func NewMacCollector(details bool) *MacCollector {
+	return &MacCollector{details: details}
+}
+
+func (c *MacCollector) GetCmd() string {
+	return "show mac address-table count"
+}
+
+func (c *MacCollector) ExtraCmds() []Command {
+	if !c.details {
+		return nil
+	}
+	return []Command{&c.macTable, &c.arpTable, &c.ipv6Neighbor}
+}
+
+var macOpts = MakeSubsystemOptsFactory("mac")
+var arpOpts = MakeSubsystemOptsFactory("arp")
+var ndOpts = MakeSubsystemOptsFactory("nd")
+
+func (c *MacCollector) Register(registry *prometheus.Registry) {
+	c.macEntriesGauge = prometheus.NewGaugeVec(macOpts("entries", "Number of MAC address table entries by VLAN and entry type"), []string{"vlan", "type"})
+	// Not a counter: the moves of an address are forgotten once it ages out of the table
+	c.macMovesGauge = prometheus.NewGaugeVec(macOpts("entry_moves", "Sum of the move counts of the MAC addresses currently learned in the VLAN"), []string{"vlan"})
+	c.macLastMoveGauge = prometheus.NewGaugeVec(macOpts("last_move_timestamp_seconds", "Unix timestamp of the most recent MAC move in the VLAN"), []string{"vlan"})
+	c.arpEntriesGauge = prometheus.NewGaugeVec(arpOpts("entries", "Number of ARP entries by VRF and interface"), []string{"vrf", "interface"})
+	c.ndEntriesGauge = prometheus.NewGaugeVec(ndOpts("entries", "Number of IPv6 neighbor entries by VRF and interface"), []string{"vrf", "interface"})
+
+	registry.MustRegister(c.macEntriesGauge)
+	if c.details {
+		registry.MustRegister(c.macMovesGauge, c.macLastMoveGauge, c.arpEntriesGauge, c.ndEntriesGauge)
+	}
+}
+
+func (c *MacCollector) UpdateMetrics() {
+	for vlan, counts := range c.VlanCounts {
+		for entryType, count := range counts {
+			if value, ok := count.(float64); ok {
+				c.macEntriesGauge.WithLabelValues(vlan, entryType).Set(value)
+			}
+		}
+	}
+
+	moves := make(map[string]int)
+	lastMove := make(map[string]float64)
+	for _, entry := range c.macTable.UnicastTable.TableEntries {
+		vlan := strconv.Itoa(entry.VlanID)
+		moves[vlan] += entry.Moves
+		if entry.LastMove > lastMove[vlan] {
+			lastMove[vlan] = entry.LastMove
+		}
+	}
+	for vlan, count := range moves {
+		c.macMovesGauge.WithLabelValues(vlan).Set(float64(count))
+		c.macLastMoveGauge.WithLabelValues(vlan).Set(lastMove[vlan])
+	}
+
+	for vrfName, vrf := range c.arpTable.Vrfs {
+		entries := make(map[string]int)
+		for _, nbr := range vrf.IpV4Neighbors {
+			entries[nbr.Interface]++
+		}
+		for ifName, count := range entries {
+			c.arpEntriesGauge.WithLabelValues(vrfName, ifName).Set(float64(count))
+		}
+	}
+
+	for vrfName, vrf := range c.ipv6Neighbor.Vrfs {
+		entries := make(map[string]int)
+		for _, nbr := range vrf.IpV6Neighbors {
+			entries[nbr.Interface]++
+		}
+		for ifName, count := range entries {
+			c.ndEntriesGauge.WithLabelValues(vrfName, ifName).Set(float64(count))
+		}
+	}
+}
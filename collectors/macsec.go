@@ -0,0 +1,120 @@
+package collectors
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type MacsecCollector struct {
+	Interfaces map[string]struct {
+		ProfileName    string `json:"profileName"`
+		ControlledPort bool   `json:"controlledPort"`
+		KeyInUse       string `json:"keyInUse"`
+	} `json:"interfaces"`
+
+	participants MacsecParticipants
+	counters     MacsecCounters
+
+	securedGauge       *prometheus.GaugeVec
+	keyServerGauge     *prometheus.GaugeVec
+	keyServerPriority  *prometheus.GaugeVec
+	keyAgeGauge        *prometheus.GaugeVec
+	livePeersGauge     *prometheus.GaugeVec
+	encryptedPktsGauge *prometheus.GaugeVec
+	validatedPktsGauge *prometheus.GaugeVec
+	notValidPktsGauge  *prometheus.GaugeVec
+	droppedPktsGauge   *prometheus.GaugeVec
+}
+
+type MacsecParticipants struct {
+	Interfaces map[string]struct {
+		// Participants keyed by connectivity association key name
+		Participants map[string]struct {
+			Principal         bool          `json:"principal"`
+			ElectedSelf       bool          `json:"electedSelf"`
+			KeyServerPriority int           `json:"keyServerPriority"`
+			SakInstallTime    float64       `json:"sakInstallTime"`
+			LivePeers         []interface{} `json:"livePeers"`
+		} `json:"participants"`
+	} `json:"interfaces"`
+}
+
+func (p *MacsecParticipants) GetCmd() string {
+	return "show mac security participants"
+}
+
+type MacsecCounters struct {
+	Interfaces map[string]struct {
+		OutPktsEncrypted int `json:"outPktsEncrypted"`
+		InPktsOk         int `json:"inPktsOk"`
+		InPktsNotValid   int `json:"inPktsNotValid"`
+		InPktsDropped    int `json:"inPktsDropped"`
+	} `json:"interfaces"`
+}
+
+func (m *MacsecCounters) GetCmd() string {
+	return "show mac security counters"
+}
+
+func (c *MacsecCollector) GetCmd() string {
+	return "show mac security"
+}
+
+func (c *MacsecCollector) ExtraCmds() []Command {
+	return []Command{&c.participants, &c.counters}
+}
+
+var macsecOpts = MakeSubsystemOptsFactory("macsec")
+
+func (c *MacsecCollector) Register(registry *prometheus.Registry) {
+	ifLabels := []string{"interface"}
+	participantLabels := []string{"interface", "ckn"}
+
+	c.securedGauge = prometheus.NewGaugeVec(macsecOpts("secured", "Whether the controlled port of the secure channel is enabled (1 if true, 0 if false)"),
+		[]string{"interface", "profile"})
+	c.keyServerGauge = prometheus.NewGaugeVec(macsecOpts("key_server", "Whether this switch is the elected key server (1 if true, 0 if false)"), participantLabels)
+	c.keyServerPriority = prometheus.NewGaugeVec(macsecOpts("key_server_priority", "Key server priority of the participant"), participantLabels)
+	c.keyAgeGauge = prometheus.NewGaugeVec(macsecOpts("key_age_seconds", "Seconds since the current secure association key was installed"), participantLabels)
+	c.livePeersGauge = prometheus.NewGaugeVec(macsecOpts("live_peers", "Number of live MKA peers of the participant"), participantLabels)
+	c.encryptedPktsGauge = prometheus.NewGaugeVec(macsecOpts("encrypted_packets", "Number of encrypted packets sent"), ifLabels)
+	c.validatedPktsGauge = prometheus.NewGaugeVec(macsecOpts("validated_packets", "Number of received packets that were successfully validated"), ifLabels)
+	c.notValidPktsGauge = prometheus.NewGaugeVec(macsecOpts("not_valid_packets", "Number of received packets that failed validation"), ifLabels)
+	c.droppedPktsGauge = prometheus.NewGaugeVec(macsecOpts("dropped_packets", "Number of received packets that were dropped"), ifLabels)
+
+	registry.MustRegister(c.securedGauge, c.keyServerGauge, c.keyServerPriority, c.keyAgeGauge, c.livePeersGauge)
+	registry.MustRegister(c.encryptedPktsGauge, c.validatedPktsGauge, c.notValidPktsGauge, c.droppedPktsGauge)
+}
+
+func (c *MacsecCollector) UpdateMetrics() {
+	for ifName, iface := range c.Interfaces {
+		secured := 0.0
+		if iface.ControlledPort {
+			secured = 1.0
+		}
+		c.securedGauge.WithLabelValues(ifName, iface.ProfileName).Set(secured)
+	}
+
+	now := float64(time.Now().Unix())
+	for ifName, iface := range c.participants.Interfaces {
+		for ckn, participant := range iface.Participants {
+			keyServer := 0.0
+			if participant.ElectedSelf {
+				keyServer = 1.0
+			}
+			c.keyServerGauge.WithLabelValues(ifName, ckn).Set(keyServer)
+			c.keyServerPriority.WithLabelValues(ifName, ckn).Set(float64(participant.KeyServerPriority))
+			if participant.SakInstallTime > 0 {
+				c.keyAgeGauge.WithLabelValues(ifName, ckn).Set(now - participant.SakInstallTime)
+			}
+			c.livePeersGauge.WithLabelValues(ifName, ckn).Set(float64(len(participant.LivePeers)))
+		}
+	}
+
+	for ifName, counters := range c.counters.Interfaces {
+		c.encryptedPktsGauge.WithLabelValues(ifName).Set(float64(counters.OutPktsEncrypted))
+		c.validatedPktsGauge.WithLabelValues(ifName).Set(float64(counters.InPktsOk))
+		c.notValidPktsGauge.WithLabelValues(ifName).Set(float64(counters.InPktsNotValid))
+		c.droppedPktsGauge.WithLabelValues(ifName).Set(float64(counters.InPktsDropped))
+	}
+}
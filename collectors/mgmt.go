@@ -0,0 +1,219 @@
+package collectors
+
+import (
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// MgmtCollector monitors the management plane: the eAPI server the exporter
+// itself talks to, logged-in users, SSH and the AAA servers.
+type MgmtCollector struct {
+	Enabled          bool             `json:"enabled"`
+	HttpServer       EapiServerStatus `json:"httpServer"`
+	HttpsServer      EapiServerStatus `json:"httpsServer"`
+	LocalHttpServer  EapiServerStatus `json:"localHttpServer"`
+	UnixSocketServer EapiServerStatus `json:"unixSocketServer"`
+	HitCount         int              `json:"hitCount"`
+	LastHitTime      float64          `json:"lastHitTime"`
+	RequestCount     int              `json:"requestCount"`
+	CommandCount     int              `json:"commandCount"`
+	BytesInCount     int              `json:"bytesInCount"`
+	BytesOutCount    int              `json:"bytesOutCount"`
+
+	users       Users
+	ssh         ManagementSsh
+	aaaCounters AaaCounters
+	tacacs      TacacsServers
+	radius      RadiusServers
+
+	eapiEnabledGauge       *prometheus.GaugeVec
+	eapiServerRunningGauge *prometheus.GaugeVec
+	eapiHitsGauge          *prometheus.GaugeVec
+	eapiLastHitGauge       *prometheus.GaugeVec
+	eapiRequestsGauge      *prometheus.GaugeVec
+	eapiCommandsGauge      *prometheus.GaugeVec
+	eapiBytesInGauge       *prometheus.GaugeVec
+	eapiBytesOutGauge      *prometheus.GaugeVec
+	sessionsGauge          *prometheus.GaugeVec
+	sshConnectionsGauge    *prometheus.GaugeVec
+	aaaAuthenticationGauge *prometheus.GaugeVec
+	aaaAuthorizationGauge  *prometheus.GaugeVec
+	aaaServerSentGauge     *prometheus.GaugeVec
+	aaaServerReceivedGauge *prometheus.GaugeVec
+	aaaServerTimeoutsGauge *prometheus.GaugeVec
+}
+
+type EapiServerStatus struct {
+	Configured bool `json:"configured"`
+	Running    bool `json:"running"`
+	Port       int  `json:"port"`
+}
+
+// Users holds the login sessions, which EOS may report as a list or keyed by line.
+type Users struct {
+	Users interface{} `json:"users"`
+}
+
+func (u *Users) GetCmd() string {
+	return "show users"
+}
+
+type ManagementSsh struct {
+	Connections interface{} `json:"connections"`
+}
+
+func (s *ManagementSsh) GetCmd() string {
+	return "show management ssh"
+}
+
+type AaaCounters struct {
+	AuthenticationSuccessful  int `json:"authenticationSuccessful"`
+	AuthenticationFailed      int `json:"authenticationFailed"`
+	AuthenticationUnavailable int `json:"authenticationUnavailable"`
+	AuthorizationAllowed      int `json:"authorizationAllowed"`
+	AuthorizationDenied       int `json:"authorizationDenied"`
+	AuthorizationUnavailable  int `json:"authorizationUnavailable"`
+}
+
+func (a *AaaCounters) GetCmd() string {
+	return "show aaa counters"
+}
+
+type TacacsServers struct {
+	TacacsServers []struct {
+		ServerInfo struct {
+			Hostname string `json:"hostname"`
+			Port     int    `json:"port"`
+		} `json:"serverInfo"`
+		MessagesSent       int `json:"messagesSent"`
+		MessagesReceived   int `json:"messagesReceived"`
+		ConnectionTimeouts int `json:"connectionTimeouts"`
+		ReceiveTimeouts    int `json:"receiveTimeouts"`
+	} `json:"tacacsServers"`
+}
+
+func (t *TacacsServers) GetCmd() string {
+	return "show tacacs"
+}
+
+type RadiusServers struct {
+	RadiusServers []struct {
+		ServerInfo struct {
+			Hostname string `json:"hostname"`
+			Authport int    `json:"authport"`
+		} `json:"serverInfo"`
+		MessagesSent     int `json:"messagesSent"`
+		MessagesReceived int `json:"messagesReceived"`
+		ReceiveTimeouts  int `json:"receiveTimeouts"`
+		SendTimeouts     int `json:"sendTimeouts"`
+	} `json:"radiusServers"`
+}
+
+func (r *RadiusServers) GetCmd() string {
+	return "show radius"
+}
+
+func (c *MgmtCollector) GetCmd() string {
+	return "show management api http-commands"
+}
+
+func (c *MgmtCollector) ExtraCmds() []Command {
+	return []Command{&c.users, &c.ssh, &c.aaaCounters, &c.tacacs, &c.radius}
+}
+
+var mgmtOpts = MakeSubsystemOptsFactory("mgmt")
+
+func (c *MgmtCollector) Register(registry *prometheus.Registry) {
+	c.eapiEnabledGauge = prometheus.NewGaugeVec(mgmtOpts("eapi_enabled", "Whether the eAPI is enabled (1 if true, 0 if false)"), []string{})
+	c.eapiServerRunningGauge = prometheus.NewGaugeVec(mgmtOpts("eapi_server_running", "Whether the eAPI server is running (1 if true, 0 if false)"), []string{"server", "port"})
+	c.eapiHitsGauge = prometheus.NewGaugeVec(mgmtOpts("eapi_hits", "Number of eAPI hits"), []string{})
+	c.eapiLastHitGauge = prometheus.NewGaugeVec(mgmtOpts("eapi_last_hit_timestamp_seconds", "Unix timestamp of the last eAPI hit"), []string{})
+	c.eapiRequestsGauge = prometheus.NewGaugeVec(mgmtOpts("eapi_requests", "Number of eAPI requests"), []string{})
+	c.eapiCommandsGauge = prometheus.NewGaugeVec(mgmtOpts("eapi_commands", "Number of commands run via eAPI"), []string{})
+	c.eapiBytesInGauge = prometheus.NewGaugeVec(mgmtOpts("eapi_bytes_in", "Number of bytes received by the eAPI server"), []string{})
+	c.eapiBytesOutGauge = prometheus.NewGaugeVec(mgmtOpts("eapi_bytes_out", "Number of bytes sent by the eAPI server"), []string{})
+
+	c.sessionsGauge = prometheus.NewGaugeVec(mgmtOpts("sessions", "Number of logged-in user sessions"), []string{})
+	c.sshConnectionsGauge = prometheus.NewGaugeVec(mgmtOpts("ssh_connections", "Number of active SSH connections"), []string{})
+
+	c.aaaAuthenticationGauge = prometheus.NewGaugeVec(mgmtOpts("aaa_authentication", "Number of AAA authentication attempts by result"), []string{"result"})
+	c.aaaAuthorizationGauge = prometheus.NewGaugeVec(mgmtOpts("aaa_authorization", "Number of AAA authorization attempts by result"), []string{"result"})
+
+	serverLabels := []string{"protocol", "server", "port"}
+	c.aaaServerSentGauge = prometheus.NewGaugeVec(mgmtOpts("aaa_server_messages_sent", "Number of messages sent to the TACACS+ or RADIUS server"), serverLabels)
+	c.aaaServerReceivedGauge = prometheus.NewGaugeVec(mgmtOpts("aaa_server_messages_received", "Number of responses received from the TACACS+ or RADIUS server"), serverLabels)
+	c.aaaServerTimeoutsGauge = prometheus.NewGaugeVec(mgmtOpts("aaa_server_timeouts", "Number of timeouts towards the TACACS+ or RADIUS server"), serverLabels)
+
+	registry.MustRegister(c.eapiEnabledGauge, c.eapiServerRunningGauge, c.eapiHitsGauge, c.eapiLastHitGauge, c.eapiRequestsGauge, c.eapiCommandsGauge, c.eapiBytesInGauge, c.eapiBytesOutGauge)
+	registry.MustRegister(c.sessionsGauge, c.sshConnectionsGauge, c.aaaAuthenticationGauge, c.aaaAuthorizationGauge)
+	registry.MustRegister(c.aaaServerSentGauge, c.aaaServerReceivedGauge, c.aaaServerTimeoutsGauge)
+}
+
+func (c *MgmtCollector) UpdateMetrics() {
+	enabled := 0.0
+	if c.Enabled {
+		enabled = 1.0
+	}
+	c.eapiEnabledGauge.WithLabelValues().Set(enabled)
+
+	servers := map[string]EapiServerStatus{
+		"http":        c.HttpServer,
+		"https":       c.HttpsServer,
+		"local_http":  c.LocalHttpServer,
+		"unix_socket": c.UnixSocketServer,
+	}
+	for name, server := range servers {
+		if !server.Configured {
+			continue
+		}
+		running := 0.0
+		if server.Running {
+			running = 1.0
+		}
+		c.eapiServerRunningGauge.WithLabelValues(name, strconv.Itoa(server.Port)).Set(running)
+	}
+
+	c.eapiHitsGauge.WithLabelValues().Set(float64(c.HitCount))
+	c.eapiLastHitGauge.WithLabelValues().Set(c.LastHitTime)
+	c.eapiRequestsGauge.WithLabelValues().Set(float64(c.RequestCount))
+	c.eapiCommandsGauge.WithLabelValues().Set(float64(c.CommandCount))
+	c.eapiBytesInGauge.WithLabelValues().Set(float64(c.BytesInCount))
+	c.eapiBytesOutGauge.WithLabelValues().Set(float64(c.BytesOutCount))
+
+	c.sessionsGauge.WithLabelValues().Set(float64(countEntries(c.users.Users)))
+	c.sshConnectionsGauge.WithLabelValues().Set(float64(countEntries(c.ssh.Connections)))
+
+	aaa := c.aaaCounters
+	c.aaaAuthenticationGauge.WithLabelValues("successful").Set(float64(aaa.AuthenticationSuccessful))
+	c.aaaAuthenticationGauge.WithLabelValues("failed").Set(float64(aaa.AuthenticationFailed))
+	c.aaaAuthenticationGauge.WithLabelValues("unavailable").Set(float64(aaa.AuthenticationUnavailable))
+	c.aaaAuthorizationGauge.WithLabelValues("allowed").Set(float64(aaa.AuthorizationAllowed))
+	c.aaaAuthorizationGauge.WithLabelValues("denied").Set(float64(aaa.AuthorizationDenied))
+	c.aaaAuthorizationGauge.WithLabelValues("unavailable").Set(float64(aaa.AuthorizationUnavailable))
+
+	for _, server := range c.tacacs.TacacsServers {
+		labels := []string{"tacacs", server.ServerInfo.Hostname, strconv.Itoa(server.ServerInfo.Port)}
+		c.aaaServerSentGauge.WithLabelValues(labels...).Set(float64(server.MessagesSent))
+		c.aaaServerReceivedGauge.WithLabelValues(labels...).Set(float64(server.MessagesReceived))
+		c.aaaServerTimeoutsGauge.WithLabelValues(labels...).Set(float64(server.ConnectionTimeouts + server.ReceiveTimeouts))
+	}
+
+	for _, server := range c.radius.RadiusServers {
+		labels := []string{"radius", server.ServerInfo.Hostname, strconv.Itoa(server.ServerInfo.Authport)}
+		c.aaaServerSentGauge.WithLabelValues(labels...).Set(float64(server.MessagesSent))
+		c.aaaServerReceivedGauge.WithLabelValues(labels...).Set(float64(server.MessagesReceived))
+		c.aaaServerTimeoutsGauge.WithLabelValues(labels...).Set(float64(server.ReceiveTimeouts + server.SendTimeouts))
+	}
+}
+
+// countEntries returns the number of entries of a decoded JSON list or object.
+func countEntries(v interface{}) int {
+	switch entries := v.(type) {
+	case []interface{}:
+		return len(entries)
+	case map[string]interface{}:
+		return len(entries)
+	}
+	return 0
+}
@@ -0,0 +1,117 @@
+package collectors
+
+import (
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type ModulesCollector struct {
+	Modules map[string]Module `json:"modules"`
+
+	redundancy RedundancyStatus
+
+	moduleInfo   *prometheus.GaugeVec
+	moduleStatus *prometheus.GaugeVec
+	modulePorts  *prometheus.GaugeVec
+
+	redundancyInfo       *prometheus.GaugeVec
+	switchoverReady      *prometheus.GaugeVec
+	peerStandby          *prometheus.GaugeVec
+	switchoverCountGauge *prometheus.GaugeVec
+}
+
+type Module struct {
+	Status           string `json:"status"`
+	TypeDescription  string `json:"typeDescription"`
+	ModelName        string `json:"modelName"`
+	SerialNumber     string `json:"serialNumber"`
+	HardwareRevision string `json:"hardwareRevision"`
+	PortCount        int    `json:"portCount"`
+}
+
+type RedundancyStatus struct {
+	MyMode              string `json:"myMode"`
+	PeerMode            string `json:"peerMode"`
+	PeerState           string `json:"peerState"`
+	UnitDesc            string `json:"unitDesc"`
+	ConfiguredProtocol  string `json:"configuredProtocol"`
+	OperationalProtocol string `json:"operationalProtocol"`
+	CommunicationDesc   string `json:"communicationDesc"`
+	SwitchoverReady     bool   `json:"switchoverReady"`
+	SwitchoverCount     int    `json:"switchoverCount"`
+}
+
+func (r *RedundancyStatus) GetCmd() string {
+	return "show redundancy status"
+}
+
+func (c *ModulesCollector) GetCmd() string {
+	return "show module"
+}
+
+func (c *ModulesCollector) ExtraCmds() []Command {
+	return []Command{&c.redundancy}
+}
+
+var moduleOpts = MakeSubsystemOptsFactory("module")
+var redundancyOpts = MakeSubsystemOptsFactory("redundancy")
+
+func (c *ModulesCollector) Register(registry *prometheus.Registry) {
+	c.moduleInfo = prometheus.NewGaugeVec(moduleOpts("info", "Meta-info about the card in each slot"),
+		[]string{"slot", "kind", "type", "model", "serial", "hardware_revision", "status"})
+	c.moduleStatus = prometheus.NewGaugeVec(moduleOpts("status", "Module status: 1 if ok, active or standby, 0 otherwise"), []string{"slot", "kind"})
+	c.modulePorts = prometheus.NewGaugeVec(moduleOpts("ports", "Number of front panel ports on the module"), []string{"slot", "kind"})
+
+	c.redundancyInfo = prometheus.NewGaugeVec(redundancyOpts("info", "Supervisor redundancy mode and protocol state"),
+		[]string{"unit", "my_mode", "peer_mode", "peer_state", "configured_protocol", "operational_protocol", "communication"})
+	c.switchoverReady = prometheus.NewGaugeVec(redundancyOpts("switchover_ready", "Whether the supervisors are ready for switchover (1 if true, 0 if false)"), []string{})
+	c.peerStandby = prometheus.NewGaugeVec(redundancyOpts("peer_standby", "Whether the peer supervisor is in standby mode (1 if true, 0 if false)"), []string{})
+	c.switchoverCountGauge = prometheus.NewGaugeVec(redundancyOpts("switchover_count", "Number of supervisor switchovers"), []string{})
+
+	registry.MustRegister(c.moduleInfo, c.moduleStatus, c.modulePorts)
+	registry.MustRegister(c.redundancyInfo, c.switchoverReady, c.peerStandby, c.switchoverCountGauge)
+}
+
+func (c *ModulesCollector) UpdateMetrics() {
+	for slot, module := range c.Modules {
+		kind := moduleKind(slot, module)
+		c.moduleInfo.WithLabelValues(slot, kind, module.TypeDescription, module.ModelName, module.SerialNumber, module.HardwareRevision, module.Status).Set(1)
+
+		status := 0.0
+		switch module.Status {
+		case "ok", "active", "standby":
+			status = 1.0
+		}
+		c.moduleStatus.WithLabelValues(slot, kind).Set(status)
+		c.modulePorts.WithLabelValues(slot, kind).Set(float64(module.PortCount))
+	}
+
+	r := c.redundancy
+	c.redundancyInfo.WithLabelValues(r.UnitDesc, r.MyMode, r.PeerMode, r.PeerState, r.ConfiguredProtocol, r.OperationalProtocol, r.CommunicationDesc).Set(1)
+	ready := 0.0
+	if r.SwitchoverReady {
+		ready = 1.0
+	}
+	c.switchoverReady.WithLabelValues().Set(ready)
+	standby := 0.0
+	if strings.EqualFold(r.PeerMode, "standby") {
+		standby = 1.0
+	}
+	c.peerStandby.WithLabelValues().Set(standby)
+	c.switchoverCountGauge.WithLabelValues().Set(float64(r.SwitchoverCount))
+}
+
+// moduleKind classifies a slot as supervisor, fabric, linecard or other.
+func moduleKind(slot string, module Module) string {
+	desc := strings.ToLower(module.TypeDescription)
+	switch {
+	case strings.HasPrefix(slot, "Fabric") || strings.Contains(desc, "fabric"):
+		return "fabric"
+	case strings.Contains(desc, "supervisor"):
+		return "supervisor"
+	case strings.HasPrefix(slot, "Linecard") || strings.Contains(desc, "linecard") || module.PortCount > 0:
+		return "linecard"
+	}
+	return "other"
+}
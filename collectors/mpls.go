@@ -0,0 +1,102 @@
+package collectors
+
+import (
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type MplsCollector struct {
+	Vrfs map[string]struct {
+		Neighbors map[string]struct {
+			State            string `json:"state"`
+			LocalLdpId       string `json:"localLdpId"`
+			NumLabelBindings int    `json:"numLabelBindings"`
+		} `json:"neighbors"`
+	} `json:"vrfs"`
+
+	lfib MplsLfibSummary
+	rsvp RsvpSessions
+
+	ldpSessionStateGauge  *prometheus.GaugeVec
+	ldpLabelBindingsGauge *prometheus.GaugeVec
+	lfibRoutesGauge       *prometheus.GaugeVec
+	rsvpLspStateGauge     *prometheus.GaugeVec
+	rsvpLspBandwidthGauge *prometheus.GaugeVec
+}
+
+// MplsLfibSummary holds the LFIB route counters keyed by counter name.
+type MplsLfibSummary map[string]interface{}
+
+func (s *MplsLfibSummary) GetCmd() string {
+	return "show mpls lfib route summary"
+}
+
+type RsvpSessions struct {
+	Sessions map[string]struct {
+		Destination string `json:"destination"`
+		TunnelID    int    `json:"tunnelId"`
+		Lsps        map[string]struct {
+			State string `json:"state"`
+			// Reserved bandwidth in bits per second
+			Bandwidth float64 `json:"bandwidth"`
+		} `json:"lsps"`
+	} `json:"sessions"`
+}
+
+func (r *RsvpSessions) GetCmd() string {
+	return "show mpls rsvp session"
+}
+
+func (c *MplsCollector) GetCmd() string {
+	return "show mpls ldp neighbor"
+}
+
+func (c *MplsCollector) ExtraCmds() []Command {
+	return []Command{&c.lfib, &c.rsvp}
+}
+
+var mplsOpts = MakeSubsystemOptsFactory("mpls")
+
+func (c *MplsCollector) Register(registry *prometheus.Registry) {
+	ldpLabels := []string{"vrf", "neighbor", "local_ldp_id"}
+	c.ldpSessionStateGauge = prometheus.NewGaugeVec(mplsOpts("ldp_session_state", "LDP session state: 1 if operational, 0 otherwise"), ldpLabels)
+	c.ldpLabelBindingsGauge = prometheus.NewGaugeVec(mplsOpts("ldp_label_bindings", "Number of label bindings received from the LDP neighbor"), ldpLabels)
+	c.lfibRoutesGauge = prometheus.NewGaugeVec(mplsOpts("lfib_routes", "LFIB route summary counters"), []string{"counter"})
+
+	lspLabels := []string{"session", "destination", "lsp"}
+	c.rsvpLspStateGauge = prometheus.NewGaugeVec(mplsOpts("rsvp_lsp_up", "RSVP-TE LSP state: 1 if up, 0 otherwise"), lspLabels)
+	c.rsvpLspBandwidthGauge = prometheus.NewGaugeVec(mplsOpts("rsvp_lsp_bandwidth", "Bandwidth reserved by the RSVP-TE LSP in bits per second"), lspLabels)
+
+	registry.MustRegister(c.ldpSessionStateGauge, c.ldpLabelBindingsGauge, c.lfibRoutesGauge, c.rsvpLspStateGauge, c.rsvpLspBandwidthGauge)
+}
+
+func (c *MplsCollector) UpdateMetrics() {
+	for vrfName, vrf := range c.Vrfs {
+		for peer, nbr := range vrf.Neighbors {
+			state := 0.0
+			if strings.EqualFold(nbr.State, "operational") {
+				state = 1.0
+			}
+			c.ldpSessionStateGauge.WithLabelValues(vrfName, peer, nbr.LocalLdpId).Set(state)
+			c.ldpLabelBindingsGauge.WithLabelValues(vrfName, peer, nbr.LocalLdpId).Set(float64(nbr.NumLabelBindings))
+		}
+	}
+
+	for counter, value := range c.lfib {
+		if count, ok := value.(float64); ok {
+			c.lfibRoutesGauge.WithLabelValues(counter).Set(count)
+		}
+	}
+
+	for name, session := range c.rsvp.Sessions {
+		for lspName, lsp := range session.Lsps {
+			up := 0.0
+			if strings.EqualFold(lsp.State, "up") {
+				up = 1.0
+			}
+			c.rsvpLspStateGauge.WithLabelValues(name, session.Destination, lspName).Set(up)
+			c.rsvpLspBandwidthGauge.WithLabelValues(name, session.Destination, lspName).Set(lsp.Bandwidth)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package collectors
+
+import (
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type NtpCollector struct {
+	Status  string `json:"status"`
+	Stratum int    `json:"stratum"`
+
+	associations NtpAssociations
+
+	synchronizedGauge     *prometheus.GaugeVec
+	stratumGauge          *prometheus.GaugeVec
+	peerOffsetGauge       *prometheus.GaugeVec
+	peerJitterGauge       *prometheus.GaugeVec
+	peerDelayGauge        *prometheus.GaugeVec
+	peerStratumGauge      *prometheus.GaugeVec
+	peerReachabilityGauge *prometheus.GaugeVec
+	peerSelectedGauge     *prometheus.GaugeVec
+}
+
+type NtpAssociations struct {
+	Peers map[string]struct {
+		Condition    string `json:"condition"`
+		Refid        string `json:"refid"`
+		StratumLevel int    `json:"stratumLevel"`
+		// Offset, jitter and delay are reported in milliseconds
+		Offset              float64 `json:"offset"`
+		Jitter              float64 `json:"jitter"`
+		Delay               float64 `json:"delay"`
+		ReachabilityHistory []bool  `json:"reachabilityHistory"`
+	} `json:"peers"`
+}
+
+func (a *NtpAssociations) GetCmd() string {
+	return "show ntp associations"
+}
+
+func (c *NtpCollector) GetCmd() string {
+	return "show ntp status"
+}
+
+func (c *NtpCollector) ExtraCmds() []Command {
+	return []Command{&c.associations}
+}
+
+var ntpOpts = MakeSubsystemOptsFactory("ntp")
+
+func (c *NtpCollector) Register(registry *prometheus.Registry) {
+	c.synchronizedGauge = prometheus.NewGaugeVec(ntpOpts("synchronized", "Whether the clock is synchronized via NTP (1 if true, 0 if false)"), []string{})
+	c.stratumGauge = prometheus.NewGaugeVec(ntpOpts("stratum", "Stratum of the local clock"), []string{})
+
+	peerLabels := []string{"peer", "refid"}
+	c.peerOffsetGauge = prometheus.NewGaugeVec(ntpOpts("peer_offset_seconds", "Clock offset to the NTP peer"), peerLabels)
+	c.peerJitterGauge = prometheus.NewGaugeVec(ntpOpts("peer_jitter_seconds", "Jitter of the NTP peer"), peerLabels)
+	c.peerDelayGauge = prometheus.NewGaugeVec(ntpOpts("peer_delay_seconds", "Round trip delay to the NTP peer"), peerLabels)
+	c.peerStratumGauge = prometheus.NewGaugeVec(ntpOpts("peer_stratum", "Stratum of the NTP peer"), peerLabels)
+	c.peerReachabilityGauge = prometheus.NewGaugeVec(ntpOpts("peer_reachability", "Fraction of the recent polls that reached the NTP peer"), peerLabels)
+	c.peerSelectedGauge = prometheus.NewGaugeVec(ntpOpts("peer_selected", "Whether the NTP peer is the selected system peer (1 if true, 0 if false)"), peerLabels)
+
+	registry.MustRegister(c.synchronizedGauge, c.stratumGauge)
+	registry.MustRegister(c.peerOffsetGauge, c.peerJitterGauge, c.peerDelayGauge, c.peerStratumGauge, c.peerReachabilityGauge, c.peerSelectedGauge)
+}
+
+func (c *NtpCollector) UpdateMetrics() {
+	synchronized := 0.0
+	if strings.HasPrefix(c.Status, "synchroni") {
+		synchronized = 1.0
+	}
+	c.synchronizedGauge.WithLabelValues().Set(synchronized)
+	c.stratumGauge.WithLabelValues().Set(float64(c.Stratum))
+
+	for addr, peer := range c.associations.Peers {
+		c.peerOffsetGauge.WithLabelValues(addr, peer.Refid).Set(peer.Offset / 1000)
+		c.peerJitterGauge.WithLabelValues(addr, peer.Refid).Set(peer.Jitter / 1000)
+		c.peerDelayGauge.WithLabelValues(addr, peer.Refid).Set(peer.Delay / 1000)
+		c.peerStratumGauge.WithLabelValues(addr, peer.Refid).Set(float64(peer.StratumLevel))
+
+		if len(peer.ReachabilityHistory) > 0 {
+			reached := 0
+			for _, ok := range peer.ReachabilityHistory {
+				if ok {
+					reached++
+				}
+			}
+			c.peerReachabilityGauge.WithLabelValues(addr, peer.Refid).Set(float64(reached) / float64(len(peer.ReachabilityHistory)))
+		}
+
+		selected := 0.0
+		if peer.Condition == "sys.peer" {
+			selected = 1.0
+		}
+		c.peerSelectedGauge.WithLabelValues(addr, peer.Refid).Set(selected)
+	}
+}
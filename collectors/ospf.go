@@ -0,0 +1,155 @@
+package collectors
+
+import (
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type OspfCollector struct {
+	Vrfs map[string]OspfNeighborVrf `json:"vrfs"`
+
+	ipv6Neighbors OspfV3Neighbors
+	instances     OspfInstances
+	database      OspfDatabaseSummary
+
+	adjacencyStateGauge *prometheus.GaugeVec
+	lsaCountGauge       *prometheus.GaugeVec
+	spfRunsGauge        *prometheus.GaugeVec
+	lastSpfAgeGauge     *prometheus.GaugeVec
+}
+
+type OspfNeighborVrf struct {
+	InstList map[string]struct {
+		OspfNeighborEntries []OspfNeighbor `json:"ospfNeighborEntries"`
+	} `json:"instList"`
+}
+
+type OspfNeighbor struct {
+	RouterID         string `json:"routerId"`
+	InterfaceName    string `json:"interfaceName"`
+	InterfaceAddress string `json:"interfaceAddress"`
+	AdjacencyState   string `json:"adjacencyState"`
+	DrState          string `json:"drState"`
+	Priority         int    `json:"priority"`
+}
+
+type OspfV3Neighbors struct {
+	Vrfs map[string]OspfNeighborVrf `json:"vrfs"`
+}
+
+func (n *OspfV3Neighbors) GetCmd() string {
+	return "show ipv6 ospf neighbor"
+}
+
+type OspfInstances struct {
+	Vrfs map[string]struct {
+		InstList map[string]struct {
+			RouterID string `json:"routerId"`
+			AreaList map[string]struct {
+				SpfCount       int     `json:"spfCount"`
+				LastSpfRunTime float64 `json:"lastSpfRunTime"`
+			} `json:"areaList"`
+		} `json:"instList"`
+	} `json:"vrfs"`
+}
+
+func (i *OspfInstances) GetCmd() string {
+	return "show ip ospf"
+}
+
+type OspfDatabaseSummary struct {
+	Vrfs map[string]struct {
+		InstList map[string]struct {
+			// LSA counts keyed by LSA type, per area
+			AreaList map[string]map[string]interface{} `json:"areaList"`
+		} `json:"instList"`
+	} `json:"vrfs"`
+}
+
+func (d *OspfDatabaseSummary) GetCmd() string {
+	return "show ip ospf database database-summary"
+}
+
+func (c *OspfCollector) GetCmd() string {
+	return "show ip ospf neighbor"
+}
+
+func (c *OspfCollector) ExtraCmds() []Command {
+	return []Command{&c.ipv6Neighbors, &c.instances, &c.database}
+}
+
+var ospfOpts = MakeSubsystemOptsFactory("ospf")
+
+func (c *OspfCollector) Register(registry *prometheus.Registry) {
+	areaLabels := []string{"vrf", "instance", "area"}
+
+	c.adjacencyStateGauge = prometheus.NewGaugeVec(ospfOpts("adjacency_state", "OSPF adjacency state: 1=Down, 2=Attempt, 3=Init, 4=2Way, 5=ExStart, 6=Exchange, 7=Loading, 8=Full"),
+		[]string{"vrf", "instance", "af", "neighbor", "interface", "address"})
+	c.lsaCountGauge = prometheus.NewGaugeVec(ospfOpts("lsa_count", "Number of LSAs in the area database by LSA type"), append(areaLabels, "type"))
+	c.spfRunsGauge = prometheus.NewGaugeVec(ospfOpts("spf_runs", "Number of SPF runs in the area"), areaLabels)
+	c.lastSpfAgeGauge = prometheus.NewGaugeVec(ospfOpts("last_spf_age_seconds", "Seconds since the last SPF run in the area"), areaLabels)
+
+	registry.MustRegister(c.adjacencyStateGauge, c.lsaCountGauge, c.spfRunsGauge, c.lastSpfAgeGauge)
+}
+
+func (c *OspfCollector) UpdateMetrics() {
+	c.updateNeighbors("ipv4", c.Vrfs)
+	c.updateNeighbors("ipv6", c.ipv6Neighbors.Vrfs)
+
+	now := float64(time.Now().Unix())
+	for vrfName, vrf := range c.instances.Vrfs {
+		for instName, inst := range vrf.InstList {
+			for areaName, area := range inst.AreaList {
+				c.spfRunsGauge.WithLabelValues(vrfName, instName, areaName).Set(float64(area.SpfCount))
+				if area.LastSpfRunTime > 0 {
+					c.lastSpfAgeGauge.WithLabelValues(vrfName, instName, areaName).Set(now - area.LastSpfRunTime)
+				}
+			}
+		}
+	}
+
+	for vrfName, vrf := range c.database.Vrfs {
+		for instName, inst := range vrf.InstList {
+			for areaName, counts := range inst.AreaList {
+				for lsaType, count := range counts {
+					if value, ok := count.(float64); ok {
+						c.lsaCountGauge.WithLabelValues(vrfName, instName, areaName, lsaType).Set(value)
+					}
+				}
+			}
+		}
+	}
+}
+
+func (c *OspfCollector) updateNeighbors(af string, vrfs map[string]OspfNeighborVrf) {
+	for vrfName, vrf := range vrfs {
+		for instName, inst := range vrf.InstList {
+			for _, nbr := range inst.OspfNeighborEntries {
+				var state float64
+				switch strings.ToLower(nbr.AdjacencyState) {
+				case "down":
+					state = 1
+				case "attempt":
+					state = 2
+				case "init":
+					state = 3
+				case "2ways", "2way", "twoway":
+					state = 4
+				case "exchstart", "exstart":
+					state = 5
+				case "exchange":
+					state = 6
+				case "loading":
+					state = 7
+				case "full":
+					state = 8
+				default:
+					state = 0
+				}
+				c.adjacencyStateGauge.WithLabelValues(vrfName, instName, af, nbr.RouterID, nbr.InterfaceName, nbr.InterfaceAddress).Set(state)
+			}
+		}
+	}
+}
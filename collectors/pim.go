@@ -0,0 +1,137 @@
+package collectors
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type PimCollector struct {
+	Vrfs map[string]struct {
+		Neighbors map[string]struct {
+			Interface       string  `json:"interface"`
+			CreationTime    float64 `json:"creationTime"`
+			LastRefreshTime float64 `json:"lastRefreshTime"`
+			HoldTime        float64 `json:"holdTime"`
+		} `json:"neighbors"`
+	} `json:"vrfs"`
+
+	mroutes MrouteCount
+	state   *PimState
+	// How long neighbors that went down are still reported
+	expiry time.Duration
+
+	neighborUpGauge       *prometheus.GaugeVec
+	neighborCreationGauge *prometheus.GaugeVec
+	neighborRefreshGauge  *prometheus.GaugeVec
+	neighborHoldTimeGauge *prometheus.GaugeVec
+	mrouteGroupsGauge     *prometheus.GaugeVec
+	mrouteRoutesGauge     *prometheus.GaugeVec
+	rpfFailuresGauge      *prometheus.GaugeVec
+}
+
+type MrouteCount struct {
+	Vrfs map[string]struct {
+		Groups map[string]struct {
+			Sources map[string]struct {
+				FwdPackets  int `json:"fwdPackets"`
+				RpfFailures int `json:"rpfFailures"`
+			} `json:"sources"`
+		} `json:"groups"`
+	} `json:"vrfs"`
+}
+
+func (m *MrouteCount) GetCmd() string {
+	return "show ip mroute count"
+}
+
+// PimState remembers when the PIM neighbors of a target were last seen, since
+// neighbors that go down are no longer listed at all.
+type PimState struct {
+	mu        sync.Mutex
+	neighbors map[pimNeighbor]time.Time
+}
+
+type pimNeighbor struct {
+	vrf       string
+	address   string
+	ifaceName string
+}
+
+func NewPimCollector(state *PimState, expiry time.Duration) *PimCollector {
+	return &PimCollector{state: state, expiry: expiry}
+}
+
+func (c *PimCollector) GetCmd() string {
+	return "show ip pim neighbor"
+}
+
+func (c *PimCollector) ExtraCmds() []Command {
+	return []Command{&c.mroutes}
+}
+
+var pimOpts = MakeSubsystemOptsFactory("pim")
+
+func (c *PimCollector) Register(registry *prometheus.Registry) {
+	nbrLabels := []string{"vrf", "neighbor", "interface"}
+	c.neighborUpGauge = prometheus.NewGaugeVec(pimOpts("neighbor_up", "Whether the PIM neighbor is up (1 if true, 0 if false). Neighbors that went down are reported until they expire, unless they went down before the exporter started"), nbrLabels)
+	c.neighborCreationGauge = prometheus.NewGaugeVec(pimOpts("neighbor_creation_timestamp_seconds", "Unix timestamp at which the PIM neighbor was discovered"), nbrLabels)
+	c.neighborRefreshGauge = prometheus.NewGaugeVec(pimOpts("neighbor_last_refresh_timestamp_seconds", "Unix timestamp of the last hello from the PIM neighbor"), nbrLabels)
+	c.neighborHoldTimeGauge = prometheus.NewGaugeVec(pimOpts("neighbor_hold_time_seconds", "Hold time advertised by the PIM neighbor"), nbrLabels)
+
+	c.mrouteGroupsGauge = prometheus.NewGaugeVec(pimOpts("mroute_groups", "Number of multicast groups in the multicast routing table"), []string{"vrf"})
+	c.mrouteRoutesGauge = prometheus.NewGaugeVec(pimOpts("mroute_routes", "Number of (S,G) routes in the multicast routing table"), []string{"vrf"})
+	c.rpfFailuresGauge = prometheus.NewGaugeVec(pimOpts("rpf_failures", "Number of packets that failed the RPF check, summed over all routes"), []string{"vrf"})
+
+	registry.MustRegister(c.neighborUpGauge, c.neighborCreationGauge, c.neighborRefreshGauge, c.neighborHoldTimeGauge)
+	registry.MustRegister(c.mrouteGroupsGauge, c.mrouteRoutesGauge, c.rpfFailuresGauge)
+}
+
+func (c *PimCollector) UpdateMetrics() {
+	now := time.Now()
+	c.state.mu.Lock()
+	if c.state.neighbors == nil {
+		c.state.neighbors = make(map[pimNeighbor]time.Time)
+	}
+	up := make(map[pimNeighbor]bool)
+	for vrfName, vrf := range c.Vrfs {
+		for addr, nbr := range vrf.Neighbors {
+			up[pimNeighbor{vrfName, addr, nbr.Interface}] = true
+			c.state.neighbors[pimNeighbor{vrfName, addr, nbr.Interface}] = now
+		}
+	}
+	for nbr, lastSeen := range c.state.neighbors {
+		value := 0.0
+		if up[nbr] {
+			value = 1.0
+		} else if now.Sub(lastSeen) > c.expiry {
+			delete(c.state.neighbors, nbr)
+			continue
+		}
+		c.neighborUpGauge.WithLabelValues(nbr.vrf, nbr.address, nbr.ifaceName).Set(value)
+	}
+	c.state.mu.Unlock()
+
+	for vrfName, vrf := range c.Vrfs {
+		for addr, nbr := range vrf.Neighbors {
+			c.neighborCreationGauge.WithLabelValues(vrfName, addr, nbr.Interface).Set(nbr.CreationTime)
+			c.neighborRefreshGauge.WithLabelValues(vrfName, addr, nbr.Interface).Set(nbr.LastRefreshTime)
+			c.neighborHoldTimeGauge.WithLabelValues(vrfName, addr, nbr.Interface).Set(nbr.HoldTime)
+		}
+	}
+
+	for vrfName, vrf := range c.mroutes.Vrfs {
+		routes := 0
+		rpfFailures := 0
+		for _, group := range vrf.Groups {
+			for _, source := range group.Sources {
+				routes++
+				rpfFailures += source.RpfFailures
+			}
+		}
+		c.mrouteGroupsGauge.WithLabelValues(vrfName).Set(float64(len(vrf.Groups)))
+		c.mrouteRoutesGauge.WithLabelValues(vrfName).Set(float64(routes))
+		c.rpfFailuresGauge.WithLabelValues(vrfName).Set(float64(rpfFailures))
+	}
+}
@@ -0,0 +1,39 @@
+package collectors
+
+import "github.com/prometheus/client_golang/prometheus"
+
+type PortSecurityCollector struct {
+	Interfaces map[string]struct {
+		MaxSecureAddr      int    `json:"maxSecureAddr"`
+		CurrentAddr        int    `json:"currentAddr"`
+		SecurityViolations int    `json:"securityViolations"`
+		SecurityAction     string `json:"securityAction"`
+	} `json:"interfaces"`
+
+	maxSecureAddrGauge *prometheus.GaugeVec
+	currentAddrGauge   *prometheus.GaugeVec
+	violationsGauge    *prometheus.GaugeVec
+}
+
+func (c *PortSecurityCollector) GetCmd() string {
+	return "show port-security"
+}
+
+var portSecurityOpts = MakeSubsystemOptsFactory("port_security")
+
+func (c *PortSecurityCollector) Register(registry *prometheus.Registry) {
+	labels := []string{"interface", "action"}
+	c.maxSecureAddrGauge = prometheus.NewGaugeVec(portSecurityOpts("max_secure_addresses", "Maximum number of secure MAC addresses on the interface"), labels)
+	c.currentAddrGauge = prometheus.NewGaugeVec(portSecurityOpts("secure_addresses", "Number of secure MAC addresses learned on the interface"), labels)
+	c.violationsGauge = prometheus.NewGaugeVec(portSecurityOpts("violations", "Number of port-security violations on the interface"), labels)
+
+	registry.MustRegister(c.maxSecureAddrGauge, c.currentAddrGauge, c.violationsGauge)
+}
+
+func (c *PortSecurityCollector) UpdateMetrics() {
+	for ifName, iface := range c.Interfaces {
+		c.maxSecureAddrGauge.WithLabelValues(ifName, iface.SecurityAction).Set(float64(iface.MaxSecureAddr))
+		c.currentAddrGauge.WithLabelValues(ifName, iface.SecurityAction).Set(float64(iface.CurrentAddr))
+		c.violationsGauge.WithLabelValues(ifName, iface.SecurityAction).Set(float64(iface.SecurityViolations))
+	}
+}
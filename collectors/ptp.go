@@ -0,0 +1,65 @@
+package collectors
+
+import (
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type PtpCollector struct {
+	PtpMode         string `json:"ptpMode"`
+	PtpClockSummary struct {
+		ClockIdentity   string `json:"clockIdentity"`
+		GmClockIdentity string `json:"gmClockIdentity"`
+		SlavePort       string `json:"slavePort"`
+		StepsRemoved    int    `json:"stepsRemoved"`
+		// Offset and delay are reported in nanoseconds
+		OffsetFromMaster float64 `json:"offsetFromMaster"`
+		MeanPathDelay    float64 `json:"meanPathDelay"`
+	} `json:"ptpClockSummary"`
+	PtpIntfSummaries map[string]struct {
+		PtpIntfVlanSummaries []struct {
+			VlanID    int    `json:"vlanId"`
+			PortState string `json:"portState"`
+		} `json:"ptpIntfVlanSummaries"`
+	} `json:"ptpIntfSummaries"`
+
+	ptpInfo          *prometheus.GaugeVec
+	offsetFromMaster *prometheus.GaugeVec
+	meanPathDelay    *prometheus.GaugeVec
+	stepsRemoved     *prometheus.GaugeVec
+	portStateGauge   *prometheus.GaugeVec
+}
+
+func (c *PtpCollector) GetCmd() string {
+	return "show ptp"
+}
+
+var ptpOpts = MakeSubsystemOptsFactory("ptp")
+
+var ptpPortStates = []string{"psInitializing", "psFaulty", "psDisabled", "psListening", "psPreMaster", "psMaster", "psPassive", "psUncalibrated", "psSlave"}
+
+func (c *PtpCollector) Register(registry *prometheus.Registry) {
+	c.ptpInfo = prometheus.NewGaugeVec(ptpOpts("info", "Meta-info about the PTP clock and its grandmaster"),
+		[]string{"mode", "clock_identity", "grandmaster_identity", "slave_port"})
+	c.offsetFromMaster = prometheus.NewGaugeVec(ptpOpts("offset_from_master_seconds", "Offset of the local clock from the master"), []string{})
+	c.meanPathDelay = prometheus.NewGaugeVec(ptpOpts("mean_path_delay_seconds", "Mean path delay to the master"), []string{})
+	c.stepsRemoved = prometheus.NewGaugeVec(ptpOpts("steps_removed", "Number of hops to the grandmaster"), []string{})
+	c.portStateGauge = prometheus.NewGaugeVec(ptpOpts("port_state", "PTP port state as a state set"), []string{"interface", "vlan", "state"})
+
+	registry.MustRegister(c.ptpInfo, c.offsetFromMaster, c.meanPathDelay, c.stepsRemoved, c.portStateGauge)
+}
+
+func (c *PtpCollector) UpdateMetrics() {
+	clock := c.PtpClockSummary
+	c.ptpInfo.WithLabelValues(c.PtpMode, clock.ClockIdentity, clock.GmClockIdentity, clock.SlavePort).Set(1)
+	c.offsetFromMaster.WithLabelValues().Set(clock.OffsetFromMaster / 1e9)
+	c.meanPathDelay.WithLabelValues().Set(clock.MeanPathDelay / 1e9)
+	c.stepsRemoved.WithLabelValues().Set(float64(clock.StepsRemoved))
+
+	for ifName, intf := range c.PtpIntfSummaries {
+		for _, vlan := range intf.PtpIntfVlanSummaries {
+			setStateSet(c.portStateGauge, ptpPortStates, vlan.PortState, ifName, strconv.Itoa(vlan.VlanID))
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package collectors
+
+import "github.com/prometheus/client_golang/prometheus"
+
+type QosCollector struct {
+	InterfaceCounters map[string]struct {
+		// Pause frame counters keyed by priority
+		RxFrames map[string]int `json:"rxFrames"`
+		TxFrames map[string]int `json:"txFrames"`
+	} `json:"interfaceCounters"`
+
+	pfcWatchdog PfcWatchdogCounters
+	ecn         EcnCounters
+
+	pfcRxGauge            *prometheus.GaugeVec
+	pfcTxGauge            *prometheus.GaugeVec
+	pfcWatchdogStuckGauge *prometheus.GaugeVec
+	pfcWatchdogDropsGauge *prometheus.GaugeVec
+	ecnMarkedPacketsGauge *prometheus.GaugeVec
+}
+
+type PfcWatchdogCounters struct {
+	Interfaces map[string]struct {
+		Priorities map[string]struct {
+			StuckCount  int `json:"stuckCount"`
+			DroppedPkts int `json:"droppedPkts"`
+		} `json:"priorities"`
+	} `json:"interfaces"`
+}
+
+func (p *PfcWatchdogCounters) GetCmd() string {
+	return "show priority-flow-control counters watchdog"
+}
+
+type EcnCounters struct {
+	Interfaces map[string]struct {
+		// ECN marked packets keyed by transmit queue
+		EcnMarkedPackets map[string]int `json:"ecnMarkedPackets"`
+	} `json:"interfaces"`
+}
+
+func (e *EcnCounters) GetCmd() string {
+	return "show qos interfaces ecn counters"
+}
+
+func (c *QosCollector) GetCmd() string {
+	return "show priority-flow-control counters"
+}
+
+func (c *QosCollector) ExtraCmds() []Command {
+	return []Command{&c.pfcWatchdog, &c.ecn}
+}
+
+var qosOpts = MakeSubsystemOptsFactory("qos")
+
+func (c *QosCollector) Register(registry *prometheus.Registry) {
+	priorityLabels := []string{"interface", "priority"}
+	c.pfcRxGauge = prometheus.NewGaugeVec(qosOpts("pfc_pause_frames_rx", "Number of PFC pause frames received for the priority"), priorityLabels)
+	c.pfcTxGauge = prometheus.NewGaugeVec(qosOpts("pfc_pause_frames_tx", "Number of PFC pause frames sent for the priority"), priorityLabels)
+	c.pfcWatchdogStuckGauge = prometheus.NewGaugeVec(qosOpts("pfc_watchdog_stuck", "Number of times the PFC watchdog detected a stuck queue"), priorityLabels)
+	c.pfcWatchdogDropsGauge = prometheus.NewGaugeVec(qosOpts("pfc_watchdog_dropped_packets", "Number of packets dropped by the PFC watchdog"), priorityLabels)
+	c.ecnMarkedPacketsGauge = prometheus.NewGaugeVec(qosOpts("ecn_marked_packets", "Number of ECN marked packets in the transmit queue"), []string{"interface", "queue"})
+
+	registry.MustRegister(c.pfcRxGauge, c.pfcTxGauge, c.pfcWatchdogStuckGauge, c.pfcWatchdogDropsGauge, c.ecnMarkedPacketsGauge)
+}
+
+func (c *QosCollector) UpdateMetrics() {
+	for ifName, counters := range c.InterfaceCounters {
+		for priority, frames := range counters.RxFrames {
+			c.pfcRxGauge.WithLabelValues(ifName, priority).Set(float64(frames))
+		}
+		for priority, frames := range counters.TxFrames {
+			c.pfcTxGauge.WithLabelValues(ifName, priority).Set(float64(frames))
+		}
+	}
+
+	for ifName, iface := range c.pfcWatchdog.Interfaces {
+		for priority, counters := range iface.Priorities {
+			c.pfcWatchdogStuckGauge.WithLabelValues(ifName, priority).Set(float64(counters.StuckCount))
+			c.pfcWatchdogDropsGauge.WithLabelValues(ifName, priority).Set(float64(counters.DroppedPkts))
+		}
+	}
+
+	for ifName, iface := range c.ecn.Interfaces {
+		for queue, packets := range iface.EcnMarkedPackets {
+			c.ecnMarkedPacketsGauge.WithLabelValues(ifName, queue).Set(float64(packets))
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package collectors
+
+import (
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type ReloadCollector struct {
+	ResetCauses []struct {
+		Description       string  `json:"description"`
+		RecommendedAction string  `json:"recommendedAction"`
+		Timestamp         float64 `json:"timestamp"`
+	} `json:"resetCauses"`
+
+	cores *TextCommand
+
+	causeInfo      *prometheus.GaugeVec
+	causeTimestamp *prometheus.GaugeVec
+	coreFilesGauge *prometheus.GaugeVec
+}
+
+func (c *ReloadCollector) GetCmd() string {
+	return "show reload cause"
+}
+
+func (c *ReloadCollector) TextCmds() []Command {
+	// EOS writes core dumps of crashed processes to /var/core
+	c.cores = NewTextCommand("dir file:/var/core")
+	return []Command{c.cores}
+}
+
+var reloadOpts = MakeSubsystemOptsFactory("reload")
+
+func (c *ReloadCollector) Register(registry *prometheus.Registry) {
+	c.causeInfo = prometheus.NewGaugeVec(reloadOpts("cause_info", "Cause of the most recent reload"), []string{"cause", "recommended_action"})
+	c.causeTimestamp = prometheus.NewGaugeVec(reloadOpts("cause_timestamp_seconds", "Unix timestamp of the most recent reload cause"), []string{})
+	c.coreFilesGauge = prometheus.NewGaugeVec(reloadOpts("core_files", "Number of core dump files stored on the switch"), []string{})
+
+	registry.MustRegister(c.causeInfo, c.causeTimestamp, c.coreFilesGauge)
+}
+
+func (c *ReloadCollector) UpdateMetrics() {
+	latest := -1
+	for i, cause := range c.ResetCauses {
+		if latest < 0 || cause.Timestamp > c.ResetCauses[latest].Timestamp {
+			latest = i
+		}
+	}
+	if latest >= 0 {
+		cause := c.ResetCauses[latest]
+		c.causeInfo.WithLabelValues(cause.Description, cause.RecommendedAction).Set(1)
+		c.causeTimestamp.WithLabelValues().Set(cause.Timestamp)
+	}
+
+	if c.cores != nil {
+		cores := 0
+		for _, name := range parseDirFiles(c.cores.Output) {
+			if strings.HasPrefix(name, "core") {
+				cores++
+			}
+		}
+		c.coreFilesGauge.WithLabelValues().Set(float64(cores))
+	}
+}
@@ -0,0 +1,119 @@
+package collectors
+
+import (
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type SflowCollector struct {
+	Enabled          bool    `json:"enabled"`
+	Running          bool    `json:"running"`
+	SampleRate       int     `json:"sampleRate"`
+	PollingInterval  float64 `json:"pollingInterval"`
+	TotalPackets     int     `json:"totalPackets"`
+	NumberOfSamples  int     `json:"numberOfSamples"`
+	SamplesDiscarded int     `json:"samplesDiscarded"`
+	Datagrams        int     `json:"datagrams"`
+	Destinations     []struct {
+		IpAddr  string `json:"ipAddr"`
+		Port    int    `json:"port"`
+		VrfName string `json:"vrfName"`
+	} `json:"destinations"`
+
+	interfaces SflowInterfaces
+
+	enabledGauge          *prometheus.GaugeVec
+	runningGauge          *prometheus.GaugeVec
+	sampleRateGauge       *prometheus.GaugeVec
+	pollingIntervalGauge  *prometheus.GaugeVec
+	destinationInfo       *prometheus.GaugeVec
+	totalPacketsGauge     *prometheus.GaugeVec
+	samplesGauge          *prometheus.GaugeVec
+	samplesDiscardedGauge *prometheus.GaugeVec
+	datagramsGauge        *prometheus.GaugeVec
+	interfaceRunningGauge *prometheus.GaugeVec
+	interfaceSamplesGauge *prometheus.GaugeVec
+}
+
+// SflowInterfaces maps each interface either to its sFlow status, or to an
+// object holding the status and the interface's sample counters.
+type SflowInterfaces struct {
+	Interfaces map[string]interface{} `json:"interfaces"`
+}
+
+func (s *SflowInterfaces) GetCmd() string {
+	return "show sflow interfaces"
+}
+
+func (c *SflowCollector) GetCmd() string {
+	return "show sflow"
+}
+
+func (c *SflowCollector) ExtraCmds() []Command {
+	return []Command{&c.interfaces}
+}
+
+var sflowOpts = MakeSubsystemOptsFactory("sflow")
+
+func (c *SflowCollector) Register(registry *prometheus.Registry) {
+	c.enabledGauge = prometheus.NewGaugeVec(sflowOpts("enabled", "Whether sFlow is enabled (1 if true, 0 if false)"), []string{})
+	c.runningGauge = prometheus.NewGaugeVec(sflowOpts("running", "Whether the sFlow agent is running (1 if true, 0 if false)"), []string{})
+	c.sampleRateGauge = prometheus.NewGaugeVec(sflowOpts("sample_rate", "Configured sFlow sample rate, one in N packets"), []string{})
+	c.pollingIntervalGauge = prometheus.NewGaugeVec(sflowOpts("polling_interval_seconds", "Configured sFlow counter polling interval"), []string{})
+	c.destinationInfo = prometheus.NewGaugeVec(sflowOpts("destination_info", "Configured sFlow collectors and the sample rate they receive"),
+		[]string{"address", "port", "vrf", "sample_rate"})
+	c.totalPacketsGauge = prometheus.NewGaugeVec(sflowOpts("total_packets", "Total number of packets seen by sFlow"), []string{})
+	c.samplesGauge = prometheus.NewGaugeVec(sflowOpts("samples", "Total number of sFlow samples"), []string{})
+	c.samplesDiscardedGauge = prometheus.NewGaugeVec(sflowOpts("samples_discarded", "Total number of discarded sFlow samples"), []string{})
+	c.datagramsGauge = prometheus.NewGaugeVec(sflowOpts("datagrams_sent", "Total number of sFlow datagrams sent to the collectors"), []string{})
+	c.interfaceRunningGauge = prometheus.NewGaugeVec(sflowOpts("interface_running", "sFlow status of the interface: 1 if running, 0 otherwise"), []string{"interface"})
+	c.interfaceSamplesGauge = prometheus.NewGaugeVec(sflowOpts("interface_samples", "Number of sFlow samples taken on the interface"), []string{"interface"})
+
+	registry.MustRegister(c.enabledGauge, c.runningGauge, c.sampleRateGauge, c.pollingIntervalGauge, c.destinationInfo)
+	registry.MustRegister(c.totalPacketsGauge, c.samplesGauge, c.samplesDiscardedGauge, c.datagramsGauge)
+	registry.MustRegister(c.interfaceRunningGauge, c.interfaceSamplesGauge)
+}
+
+func (c *SflowCollector) UpdateMetrics() {
+	enabled := 0.0
+	if c.Enabled {
+		enabled = 1.0
+	}
+	c.enabledGauge.WithLabelValues().Set(enabled)
+	running := 0.0
+	if c.Running {
+		running = 1.0
+	}
+	c.runningGauge.WithLabelValues().Set(running)
+
+	c.sampleRateGauge.WithLabelValues().Set(float64(c.SampleRate))
+	c.pollingIntervalGauge.WithLabelValues().Set(c.PollingInterval)
+	for _, dest := range c.Destinations {
+		c.destinationInfo.WithLabelValues(dest.IpAddr, strconv.Itoa(dest.Port), dest.VrfName, strconv.Itoa(c.SampleRate)).Set(1)
+	}
+
+	c.totalPacketsGauge.WithLabelValues().Set(float64(c.TotalPackets))
+	c.samplesGauge.WithLabelValues().Set(float64(c.NumberOfSamples))
+	c.samplesDiscardedGauge.WithLabelValues().Set(float64(c.SamplesDiscarded))
+	c.datagramsGauge.WithLabelValues().Set(float64(c.Datagrams))
+
+	for ifName, iface := range c.interfaces.Interfaces {
+		var status string
+		switch v := iface.(type) {
+		case string:
+			status = v
+		case map[string]interface{}:
+			status, _ = v["status"].(string)
+			if samples, ok := v["samples"].(float64); ok {
+				c.interfaceSamplesGauge.WithLabelValues(ifName).Set(samples)
+			}
+		}
+
+		ifRunning := 0.0
+		if status == "running" {
+			ifRunning = 1.0
+		}
+		c.interfaceRunningGauge.WithLabelValues(ifName).Set(ifRunning)
+	}
+}
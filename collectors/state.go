@@ -0,0 +1,26 @@
+package collectors
+
+import "sync"
+
+// TargetStates holds collector state of every target that is kept between
+// scrapes, as collectors themselves only live for a single scrape.
+type TargetStates[T any] struct {
+	mu     sync.Mutex
+	states map[string]*T
+}
+
+func NewTargetStates[T any]() *TargetStates[T] {
+	return &TargetStates[T]{states: make(map[string]*T)}
+}
+
+// Get returns the state of the target, creating it on first use.
+func (s *TargetStates[T]) Get(target string) *T {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	state, ok := s.states[target]
+	if !ok {
+		state = new(T)
+		s.states[target] = state
+	}
+	return state
+}
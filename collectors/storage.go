@@ -0,0 +1,110 @@
+package collectors
+
+import (
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type StorageCollector struct {
+	FileSystems []struct {
+		Prefix string `json:"prefix"`
+		FsType string `json:"fsType"`
+		Size   int    `json:"size"`
+		Free   int    `json:"free"`
+	} `json:"fileSystems"`
+
+	bootConfig BootConfig
+	flash      *TextCommand
+
+	sizeGauge             *prometheus.GaugeVec
+	freeGauge             *prometheus.GaugeVec
+	swiImagesGauge        *prometheus.GaugeVec
+	bootImagePresentGauge *prometheus.GaugeVec
+}
+
+type BootConfig struct {
+	SoftwareImage string `json:"softwareImage"`
+}
+
+func (b *BootConfig) GetCmd() string {
+	return "show boot-config"
+}
+
+func (c *StorageCollector) GetCmd() string {
+	return "show file systems"
+}
+
+func (c *StorageCollector) ExtraCmds() []Command {
+	return []Command{&c.bootConfig}
+}
+
+func (c *StorageCollector) TextCmds() []Command {
+	c.flash = NewTextCommand("dir flash:")
+	return []Command{c.flash}
+}
+
+var storageOpts = MakeSubsystemOptsFactory("storage")
+
+func (c *StorageCollector) Register(registry *prometheus.Registry) {
+	fsLabels := []string{"filesystem", "type"}
+	c.sizeGauge = prometheus.NewGaugeVec(storageOpts("size_bytes", "Total size of the filesystem in bytes"), fsLabels)
+	c.freeGauge = prometheus.NewGaugeVec(storageOpts("free_bytes", "Free space on the filesystem in bytes"), fsLabels)
+	c.swiImagesGauge = prometheus.NewGaugeVec(storageOpts("swi_images", "Number of EOS software images stored on flash"), []string{})
+	c.bootImagePresentGauge = prometheus.NewGaugeVec(storageOpts("boot_image_present", "Whether the configured boot image exists on flash (1 if true, 0 if false)"), []string{"image"})
+
+	registry.MustRegister(c.sizeGauge, c.freeGauge, c.swiImagesGauge, c.bootImagePresentGauge)
+}
+
+func (c *StorageCollector) UpdateMetrics() {
+	for _, fs := range c.FileSystems {
+		c.sizeGauge.WithLabelValues(fs.Prefix, fs.FsType).Set(float64(fs.Size))
+		c.freeGauge.WithLabelValues(fs.Prefix, fs.FsType).Set(float64(fs.Free))
+	}
+
+	if c.flash == nil {
+		return
+	}
+
+	files := make(map[string]bool)
+	for _, name := range parseDirFiles(c.flash.Output) {
+		files[name] = true
+	}
+
+	images := 0
+	for name := range files {
+		if strings.HasSuffix(strings.ToLower(name), ".swi") {
+			images++
+		}
+	}
+	c.swiImagesGauge.WithLabelValues().Set(float64(images))
+
+	// Only images in the top directory of flash can be checked against its listing
+	image := c.bootConfig.SoftwareImage
+	if name, ok := strings.CutPrefix(image, "flash:"); ok {
+		name = strings.TrimPrefix(name, "/")
+		if strings.Contains(name, "/") {
+			return
+		}
+		present := 0.0
+		if files[name] {
+			present = 1.0
+		}
+		c.bootImagePresentGauge.WithLabelValues(image).Set(present)
+	}
+}
+
+// parseDirFiles returns the names of the files, but not the directories, in
+// the output of "dir". Entries are listed with their permissions first and
+// their name last, e.g.
+// "       -rwx   982380132           Oct 19 08:12  EOS-4.32.2F.swi"
+func parseDirFiles(output string) []string {
+	var files []string
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) > 1 && strings.HasPrefix(fields[0], "-") {
+			files = append(files, fields[len(fields)-1])
+		}
+	}
+	return files
+}
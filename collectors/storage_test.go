@@ -0,0 +1,48 @@
+package collectors
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseDirFiles(t *testing.T) {
+	tests := []struct {
+		output string
+		want   []string
+	}{
+		{
+			output: `Directory of flash:/
+
+       -rwx   982380132           Mar 21 11:13  EOS-4.32.2F.swi
+       -rwx   951232512            Jan 9 16:02  EOS-4.30.5M.swi
+       -rwx          29           Mar 21 11:13  boot-config
+       drwx        4096            Oct 4 10:54  debug
+       drwx        4096           Mar 21 11:15  persist
+       -rwx        7420           Mar 21 11:14  startup-config
+       -rwx        1138           Mar 21 11:14  zerotouch-config
+
+3957452800 bytes total (1057009664 bytes free)
+`,
+			want: []string{"EOS-4.32.2F.swi", "EOS-4.30.5M.swi", "boot-config", "startup-config", "zerotouch-config"},
+		},
+		{
+			output: `Directory of file:/var/core/
+
+       -rw-     1843532           Oct 19 08:12  core.2042.1729325521.Bgp.gz
+       drwx        4096           Oct 19 08:12  minidump
+
+2147483648 bytes total (2145640116 bytes free)
+`,
+			want: []string{"core.2042.1729325521.Bgp.gz"},
+		},
+		{
+			output: "Directory of file:/var/core/\n\nNo files in directory\n",
+			want:   nil,
+		},
+	}
+	for _, tt := range tests {
+		if got := parseDirFiles(tt.output); !slices.Equal(got, tt.want) {
+			t.Errorf("parseDirFiles(%q) = %q, want %q", tt.output, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package collectors
+
+import "github.com/prometheus/client_golang/prometheus"
+
+type StormControlCollector struct {
+	Interfaces map[string]struct {
+		// Settings and counters keyed by traffic type (broadcast, multicast, unknown-unicast)
+		TrafficTypeInfo map[string]struct {
+			Level     float64 `json:"level"`
+			Threshold float64 `json:"threshold"`
+			Drop      int     `json:"drop"`
+		} `json:"trafficTypeInfo"`
+	} `json:"interfaces"`
+
+	levelGauge     *prometheus.GaugeVec
+	thresholdGauge *prometheus.GaugeVec
+	dropsGauge     *prometheus.GaugeVec
+}
+
+func (c *StormControlCollector) GetCmd() string {
+	return "show storm-control"
+}
+
+var stormControlOpts = MakeSubsystemOptsFactory("storm_control")
+
+func (c *StormControlCollector) Register(registry *prometheus.Registry) {
+	labels := []string{"interface", "type"}
+	c.levelGauge = prometheus.NewGaugeVec(stormControlOpts("level_percent", "Configured storm-control level in percent of the interface bandwidth"), labels)
+	c.thresholdGauge = prometheus.NewGaugeVec(stormControlOpts("threshold", "Storm-control threshold in packets per second"), labels)
+	c.dropsGauge = prometheus.NewGaugeVec(stormControlOpts("drops", "Number of packets dropped by storm-control"), labels)
+
+	registry.MustRegister(c.levelGauge, c.thresholdGauge, c.dropsGauge)
+}
+
+func (c *StormControlCollector) UpdateMetrics() {
+	for ifName, iface := range c.Interfaces {
+		for trafficType, info := range iface.TrafficTypeInfo {
+			c.levelGauge.WithLabelValues(ifName, trafficType).Set(info.Level)
+			c.thresholdGauge.WithLabelValues(ifName, trafficType).Set(info.Threshold)
+			c.dropsGauge.WithLabelValues(ifName, trafficType).Set(float64(info.Drop))
+		}
+	}
+}
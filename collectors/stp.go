@@ -0,0 +1,141 @@
+package collectors
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type StpCollector struct {
+	SpanningTreeInstances map[string]StpInstance `json:"spanningTreeInstances"`
+
+	counters    StpCounters
+	errdisabled ErrdisabledInterfaces
+
+	rootBridgeInfo        *prometheus.GaugeVec
+	isRootBridge          *prometheus.GaugeVec
+	topologyChanges       *prometheus.GaugeVec
+	lastTopologyChangeAge *prometheus.GaugeVec
+	portRole              *prometheus.GaugeVec
+	portState             *prometheus.GaugeVec
+	bpduSent              *prometheus.GaugeVec
+	bpduReceived          *prometheus.GaugeVec
+	bpduTaggedErrors      *prometheus.GaugeVec
+	bpduOtherErrors       *prometheus.GaugeVec
+	bpduGuardErrdisabled  *prometheus.GaugeVec
+}
+
+type StpInstance struct {
+	Protocol               string                  `json:"protocol"`
+	Bridge                 StpBridge               `json:"bridge"`
+	RootBridge             StpBridge               `json:"rootBridge"`
+	TopologyChanges        int                     `json:"topologyChanges"`
+	LastTopologyChangeTime float64                 `json:"lastTopologyChangeTime"`
+	Interfaces             map[string]StpInterface `json:"interfaces"`
+}
+
+type StpBridge struct {
+	Priority          int    `json:"priority"`
+	SystemIdExtension int    `json:"systemIdExtension"`
+	MacAddress        string `json:"macAddress"`
+}
+
+// id formats the bridge ID the way EOS prints it, e.g. "32768.001c.7300.0001".
+func (b StpBridge) id() string {
+	return fmt.Sprintf("%d.%s", b.Priority+b.SystemIdExtension, b.MacAddress)
+}
+
+type StpInterface struct {
+	Role  string `json:"role"`
+	State string `json:"state"`
+	Cost  int    `json:"cost"`
+}
+
+type StpCounters struct {
+	Interfaces map[string]struct {
+		BpduSent        int `json:"bpduSent"`
+		BpduReceived    int `json:"bpduReceived"`
+		BpduTaggedError int `json:"bpduTaggedError"`
+		BpduOtherError  int `json:"bpduOtherError"`
+	} `json:"interfaces"`
+}
+
+func (s *StpCounters) GetCmd() string {
+	return "show spanning-tree counters"
+}
+
+func (c *StpCollector) GetCmd() string {
+	return "show spanning-tree detail"
+}
+
+func (c *StpCollector) ExtraCmds() []Command {
+	return []Command{&c.counters, &c.errdisabled}
+}
+
+var stpOpts = MakeSubsystemOptsFactory("stp")
+
+var stpPortRoles = []string{"root", "designated", "alternate", "backup", "master", "disabled"}
+var stpPortStates = []string{"forwarding", "learning", "listening", "blocking", "discarding", "disabled"}
+
+func (c *StpCollector) Register(registry *prometheus.Registry) {
+	instanceLabels := []string{"instance"}
+	c.rootBridgeInfo = prometheus.NewGaugeVec(stpOpts("root_bridge_info", "Root bridge and root port of the spanning-tree instance"),
+		[]string{"instance", "protocol", "root_bridge_id", "root_port"})
+	c.isRootBridge = prometheus.NewGaugeVec(stpOpts("is_root_bridge", "Whether this switch is the root bridge of the instance (1 if true, 0 if false)"), instanceLabels)
+	c.topologyChanges = prometheus.NewGaugeVec(stpOpts("topology_changes", "Number of topology changes in the instance"), instanceLabels)
+	c.lastTopologyChangeAge = prometheus.NewGaugeVec(stpOpts("last_topology_change_age_seconds", "Seconds since the last topology change in the instance"), instanceLabels)
+
+	c.portRole = prometheus.NewGaugeVec(stpOpts("port_role", "Spanning-tree port role as a state set"), []string{"instance", "interface", "role"})
+	c.portState = prometheus.NewGaugeVec(stpOpts("port_state", "Spanning-tree port state as a state set"), []string{"instance", "interface", "state"})
+
+	ifLabels := []string{"interface"}
+	c.bpduSent = prometheus.NewGaugeVec(stpOpts("bpdu_sent", "Number of BPDUs sent on the interface"), ifLabels)
+	c.bpduReceived = prometheus.NewGaugeVec(stpOpts("bpdu_received", "Number of BPDUs received on the interface"), ifLabels)
+	c.bpduTaggedErrors = prometheus.NewGaugeVec(stpOpts("bpdu_tagged_errors", "Number of tagged BPDUs received in error on the interface"), ifLabels)
+	c.bpduOtherErrors = prometheus.NewGaugeVec(stpOpts("bpdu_other_errors", "Number of other BPDU errors on the interface"), ifLabels)
+	c.bpduGuardErrdisabled = prometheus.NewGaugeVec(stpOpts("bpduguard_errdisabled", "Interface err-disabled by BPDU guard (1 if true)"), ifLabels)
+
+	registry.MustRegister(c.rootBridgeInfo, c.isRootBridge, c.topologyChanges, c.lastTopologyChangeAge, c.portRole, c.portState)
+	registry.MustRegister(c.bpduSent, c.bpduReceived, c.bpduTaggedErrors, c.bpduOtherErrors, c.bpduGuardErrdisabled)
+}
+
+func (c *StpCollector) UpdateMetrics() {
+	now := float64(time.Now().Unix())
+
+	for name, inst := range c.SpanningTreeInstances {
+		rootPort := ""
+		for ifName, iface := range inst.Interfaces {
+			if iface.Role == "root" {
+				rootPort = ifName
+			}
+			setStateSet(c.portRole, stpPortRoles, iface.Role, name, ifName)
+			setStateSet(c.portState, stpPortStates, iface.State, name, ifName)
+		}
+
+		c.rootBridgeInfo.WithLabelValues(name, inst.Protocol, inst.RootBridge.id(), rootPort).Set(1)
+		isRoot := 0.0
+		if inst.Bridge.id() == inst.RootBridge.id() {
+			isRoot = 1.0
+		}
+		c.isRootBridge.WithLabelValues(name).Set(isRoot)
+		c.topologyChanges.WithLabelValues(name).Set(float64(inst.TopologyChanges))
+		if inst.LastTopologyChangeTime > 0 {
+			c.lastTopologyChangeAge.WithLabelValues(name).Set(now - inst.LastTopologyChangeTime)
+		}
+	}
+
+	for ifName, counters := range c.counters.Interfaces {
+		c.bpduSent.WithLabelValues(ifName).Set(float64(counters.BpduSent))
+		c.bpduReceived.WithLabelValues(ifName).Set(float64(counters.BpduReceived))
+		c.bpduTaggedErrors.WithLabelValues(ifName).Set(float64(counters.BpduTaggedError))
+		c.bpduOtherErrors.WithLabelValues(ifName).Set(float64(counters.BpduOtherError))
+	}
+
+	for ifName, status := range c.errdisabled.InterfaceStatuses {
+		if slices.Contains(status.Causes, "bpduguard") {
+			c.bpduGuardErrdisabled.WithLabelValues(ifName).Set(1)
+		}
+	}
+}
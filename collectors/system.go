@@ -0,0 +1,194 @@
+package collectors
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type SystemCollector struct {
+	TimeInfo struct {
+		LoadAvg []float64 `json:"loadAvg"`
+	} `json:"timeInfo"`
+	CpuInfo   map[string]map[string]float64 `json:"cpuInfo"`
+	Processes map[string]TopProcess         `json:"processes"`
+
+	agentUptime AgentUptime
+	agentCrash  *TextCommand
+
+	cpuUtilizationGauge      *prometheus.GaugeVec
+	loadAverageGauge         *prometheus.GaugeVec
+	agentMemoryGauge         *prometheus.GaugeVec
+	agentCpuUtilizationGauge *prometheus.GaugeVec
+	agentStartTimeGauge      *prometheus.GaugeVec
+	agentRestartsGauge       *prometheus.GaugeVec
+	agentCrashesGauge        *prometheus.GaugeVec
+}
+
+type TopProcess struct {
+	Cmd    string  `json:"cmd"`
+	CpuPct float64 `json:"cpuPct"`
+	MemPct float64 `json:"memPct"`
+	// Memory columns are reported like in top, either as plain KiB or with a unit suffix
+	ResidentMem interface{} `json:"residentMem"`
+	VirtMem     interface{} `json:"virtMem"`
+}
+
+type AgentUptime struct {
+	Agents map[string]struct {
+		AgentStartTime float64 `json:"agentStartTime"`
+		RestartCount   int     `json:"restartCount"`
+	} `json:"agents"`
+}
+
+func (a *AgentUptime) GetCmd() string {
+	return "show agent uptime"
+}
+
+func (c *SystemCollector) GetCmd() string {
+	return "show processes top once"
+}
+
+func (c *SystemCollector) ExtraCmds() []Command {
+	return []Command{&c.agentUptime}
+}
+
+func (c *SystemCollector) TextCmds() []Command {
+	c.agentCrash = NewTextCommand("show agent logs crash")
+	return []Command{c.agentCrash}
+}
+
+var systemOpts = MakeSubsystemOptsFactory("system")
+
+// Every crash log entry starts with a header naming the agent's log file, e.g.
+// "===> /var/log/agents/Bgp-2042 Tue Mar  5 10:12:01 2024 <==="
+var agentCrashHeader = regexp.MustCompile(`^===> /var/log/agents/(\S+)-\d+ `)
+
+// Key of the CPU utilization summed up over all CPUs
+const cpuAggregate = "%Cpu(s)"
+
+// top truncates command names to the length of the kernel's task name
+const topCmdLength = 15
+
+var loadAverageIntervals = []string{"1m", "5m", "15m"}
+
+func (c *SystemCollector) Register(registry *prometheus.Registry) {
+	c.cpuUtilizationGauge = prometheus.NewGaugeVec(systemOpts("cpu_utilization", "CPU utilization in percent by mode"), []string{"mode"})
+	c.loadAverageGauge = prometheus.NewGaugeVec(systemOpts("load_average", "System load average"), []string{"interval"})
+
+	agentLabels := []string{"agent"}
+	c.agentMemoryGauge = prometheus.NewGaugeVec(systemOpts("agent_memory_resident_bytes", "Resident memory of all processes of an EOS agent, in bytes"), agentLabels)
+	c.agentCpuUtilizationGauge = prometheus.NewGaugeVec(systemOpts("agent_cpu_utilization", "CPU utilization of all processes of an EOS agent, in percent"), agentLabels)
+	c.agentStartTimeGauge = prometheus.NewGaugeVec(systemOpts("agent_start_timestamp_seconds", "Unix timestamp at which the EOS agent was last started"), agentLabels)
+	c.agentRestartsGauge = prometheus.NewGaugeVec(systemOpts("agent_restarts", "Number of times the EOS agent has been restarted"), agentLabels)
+	c.agentCrashesGauge = prometheus.NewGaugeVec(systemOpts("agent_crashes", "Number of crash logs recorded for the EOS agent"), agentLabels)
+
+	registry.MustRegister(c.cpuUtilizationGauge, c.loadAverageGauge)
+	registry.MustRegister(c.agentMemoryGauge, c.agentCpuUtilizationGauge, c.agentStartTimeGauge, c.agentRestartsGauge, c.agentCrashesGauge)
+}
+
+func (c *SystemCollector) UpdateMetrics() {
+	// top lists the aggregate of all CPUs, and each CPU on its own only if configured to
+	modes, ok := c.CpuInfo[cpuAggregate]
+	if !ok && len(c.CpuInfo) == 1 {
+		for _, m := range c.CpuInfo {
+			modes = m
+		}
+	}
+	for mode, pct := range modes {
+		c.cpuUtilizationGauge.WithLabelValues(mode).Set(pct)
+	}
+
+	for i, load := range c.TimeInfo.LoadAvg {
+		if i < len(loadAverageIntervals) {
+			c.loadAverageGauge.WithLabelValues(loadAverageIntervals[i]).Set(load)
+		}
+	}
+
+	crashes := make(map[string]int)
+	if c.agentCrash != nil {
+		for _, line := range strings.Split(c.agentCrash.Output, "\n") {
+			if match := agentCrashHeader.FindStringSubmatch(line); match != nil {
+				crashes[match[1]]++
+			}
+		}
+	}
+
+	// Agents may run several processes, so their usage is summed up by agent name
+	memory := make(map[string]float64)
+	cpu := make(map[string]float64)
+	for _, proc := range c.Processes {
+		name, ok := c.agentForProcess(proc.Cmd)
+		if !ok {
+			continue
+		}
+		memory[name] += parseTopMemory(proc.ResidentMem)
+		cpu[name] += proc.CpuPct
+	}
+
+	for name, agent := range c.agentUptime.Agents {
+		c.agentStartTimeGauge.WithLabelValues(name).Set(agent.AgentStartTime)
+		c.agentRestartsGauge.WithLabelValues(name).Set(float64(agent.RestartCount))
+		c.agentCrashesGauge.WithLabelValues(name).Set(float64(crashes[name]))
+		if mem, ok := memory[name]; ok {
+			c.agentMemoryGauge.WithLabelValues(name).Set(mem)
+			c.agentCpuUtilizationGauge.WithLabelValues(name).Set(cpu[name])
+		}
+	}
+
+	// Crash logs can outlive the agent that wrote them
+	for name, count := range crashes {
+		if _, ok := c.agentUptime.Agents[name]; !ok {
+			c.agentCrashesGauge.WithLabelValues(name).Set(float64(count))
+		}
+	}
+}
+
+// agentForProcess returns the agent a top process belongs to. Command names of
+// agents with long names are truncated, so those are matched by prefix.
+func (c *SystemCollector) agentForProcess(cmd string) (string, bool) {
+	if _, ok := c.agentUptime.Agents[cmd]; ok {
+		return cmd, true
+	}
+	if len(cmd) < topCmdLength {
+		return "", false
+	}
+	match := ""
+	for name := range c.agentUptime.Agents {
+		if strings.HasPrefix(name, cmd) {
+			// Ambiguous prefixes can't be attributed to one agent
+			if match != "" {
+				return "", false
+			}
+			match = name
+		}
+	}
+	return match, match != ""
+}
+
+// parseTopMemory converts a top memory column (KiB, or suffixed with m/g/t) into bytes.
+func parseTopMemory(value interface{}) float64 {
+	switch v := value.(type) {
+	case float64:
+		return v * 1024
+	case string:
+		multiplier := 1024.0
+		v = strings.ToLower(strings.TrimSpace(v))
+		switch {
+		case strings.HasSuffix(v, "m"):
+			multiplier = 1024 * 1024
+		case strings.HasSuffix(v, "g"):
+			multiplier = 1024 * 1024 * 1024
+		case strings.HasSuffix(v, "t"):
+			multiplier = 1024 * 1024 * 1024 * 1024
+		}
+		num, err := strconv.ParseFloat(strings.TrimRight(v, "kmgt"), 64)
+		if err != nil {
+			return 0
+		}
+		return num * multiplier
+	}
+	return 0
+}
@@ -0,0 +1,79 @@
+package collectors
+
+import "testing"
+
+func TestParseTopMemory(t *testing.T) {
+	tests := []struct {
+		value interface{}
+		want  float64
+	}{
+		{float64(2048), 2048 * 1024},
+		{"2048", 2048 * 1024},
+		{"512m", 512 * 1024 * 1024},
+		{"1.5g", 1.5 * 1024 * 1024 * 1024},
+		{"0.1t", 0.1 * 1024 * 1024 * 1024 * 1024},
+		{" 12M ", 12 * 1024 * 1024},
+		{"n/a", 0},
+		{nil, 0},
+	}
+	for _, tt := range tests {
+		if got := parseTopMemory(tt.value); got != tt.want {
+			t.Errorf("parseTopMemory(%#v) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestAgentCrashHeader(t *testing.T) {
+	tests := []struct {
+		line  string
+		agent string
+	}{
+		{"===> /var/log/agents/Bgp-2042 Tue Mar  5 10:12:01 2024 <===", "Bgp"},
+		{"===> /var/log/agents/StpTopology-1877 Tue Mar  5 10:12:01 2024 <===", "StpTopology"},
+		{"===> /var/log/agents/Sand-Fap-3107 Tue Mar  5 10:12:01 2024 <===", "Sand-Fap"},
+		{"Traceback (most recent call last):", ""},
+		{"===> /var/log/messages Tue Mar  5 10:12:01 2024 <===", ""},
+	}
+	for _, tt := range tests {
+		agent := ""
+		if match := agentCrashHeader.FindStringSubmatch(tt.line); match != nil {
+			agent = match[1]
+		}
+		if agent != tt.agent {
+			t.Errorf("agentCrashHeader on %q matched %q, want %q", tt.line, agent, tt.agent)
+		}
+	}
+}
+
+func TestAgentForProcess(t *testing.T) {
+	c := &SystemCollector{}
+	c.agentUptime.Agents = map[string]struct {
+		AgentStartTime float64 `json:"agentStartTime"`
+		RestartCount   int     `json:"restartCount"`
+	}{
+		"Bgp":                    {},
+		"IpRibBgpRouteAgent":     {},
+		"SandMcastAgentV4":       {},
+		"StrataL2AgentUnicast":   {},
+		"StrataL2AgentUnicastV6": {},
+	}
+
+	tests := []struct {
+		cmd   string
+		agent string
+		ok    bool
+	}{
+		{"Bgp", "Bgp", true},
+		{"IpRibBgpRouteAg", "IpRibBgpRouteAgent", true},
+		{"SandMcastAgentV", "SandMcastAgentV4", true},
+		{"StrataL2AgentUn", "", false},
+		{"IpRib", "", false},
+		{"bash", "", false},
+	}
+	for _, tt := range tests {
+		agent, ok := c.agentForProcess(tt.cmd)
+		if agent != tt.agent || ok != tt.ok {
+			t.Errorf("agentForProcess(%q) = %q, %v, want %q, %v", tt.cmd, agent, ok, tt.agent, tt.ok)
+		}
+	}
+}
@@ -19,6 +19,7 @@ type VersionCollector struct {
 
 	metaInfo    *prometheus.GaugeVec
 	uptime      *prometheus.GaugeVec
+	bootup      *prometheus.GaugeVec
 	memoryTotal *prometheus.GaugeVec
 	memoryFree  *prometheus.GaugeVec
 }
@@ -36,19 +37,21 @@ func (c *VersionCollector) Register(registry *prometheus.Registry) {
 
 	// Switch Uptime
 	c.uptime = prometheus.NewGaugeVec(versionOpts("uptime", "Uptime"), []string{})
+	c.bootup = prometheus.NewGaugeVec(versionOpts("bootup_timestamp_seconds", "Unix timestamp of the last boot"), []string{})
 
 	// Switch Memory Consumption
 	c.memoryTotal = prometheus.NewGaugeVec(versionOpts("memory_total", "Memory Total"), []string{})
 	c.memoryFree = prometheus.NewGaugeVec(versionOpts("memory_free", "Memory Free"), []string{})
 
-	registry.MustRegister(c.metaInfo, c.uptime, c.memoryTotal, c.memoryFree)
+	registry.MustRegister(c.metaInfo, c.uptime, c.bootup, c.memoryTotal, c.memoryFree)
 }
 
 func (c *VersionCollector) UpdateMetrics() {
 	// Record metadata
 	c.metaInfo.WithLabelValues(c.ModelName, c.SystemMacAddress, c.Version, c.SerialNumber, c.Architecture, c.HardwareRevision).Set(1)
-	// Record Uptime & Memory Consumption
+	// Record Uptime, Bootup Time & Memory Consumption
 	c.uptime.WithLabelValues().Set(c.Uptime)
+	c.bootup.WithLabelValues().Set(c.BootupTimestamp)
 	c.memoryTotal.WithLabelValues().Set(float64(c.MemoryTotal))
 	c.memoryFree.WithLabelValues().Set(float64(c.MemoryFree))
 }
@@ -0,0 +1,98 @@
+package collectors
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// VlanCollector uses "show vlan", whose JSON output already holds everything
+// "show vlan brief" prints, plus the SVIs from "show ip interface brief".
+type VlanCollector struct {
+	Vlans map[string]struct {
+		Name       string                 `json:"name"`
+		Status     string                 `json:"status"`
+		Dynamic    bool                   `json:"dynamic"`
+		Interfaces map[string]interface{} `json:"interfaces"`
+	} `json:"vlans"`
+
+	ipInterfaces IpInterfaceBrief
+
+	vlanInfo         *prometheus.GaugeVec
+	activePorts      *prometheus.GaugeVec
+	noActiveMembers  *prometheus.GaugeVec
+	sviStatusGauge   *prometheus.GaugeVec
+	sviProtocolGauge *prometheus.GaugeVec
+}
+
+type IpInterfaceBrief struct {
+	Interfaces map[string]struct {
+		InterfaceStatus    string `json:"interfaceStatus"`
+		LineProtocolStatus string `json:"lineProtocolStatus"`
+	} `json:"interfaces"`
+}
+
+func (i *IpInterfaceBrief) GetCmd() string {
+	return "show ip interface brief"
+}
+
+func (c *VlanCollector) GetCmd() string {
+	return "show vlan"
+}
+
+func (c *VlanCollector) ExtraCmds() []Command {
+	return []Command{&c.ipInterfaces}
+}
+
+var vlanOpts = MakeSubsystemOptsFactory("vlan")
+
+func (c *VlanCollector) Register(registry *prometheus.Registry) {
+	c.vlanInfo = prometheus.NewGaugeVec(vlanOpts("info", "Meta-info about each configured VLAN"), []string{"vlan", "name", "status", "dynamic"})
+	c.activePorts = prometheus.NewGaugeVec(vlanOpts("active_ports", "Number of active ports in the VLAN"), []string{"vlan"})
+	c.noActiveMembers = prometheus.NewGaugeVec(vlanOpts("no_active_members", "Whether the VLAN is configured but has no active member ports (1 if true, 0 if false)"), []string{"vlan"})
+	c.sviStatusGauge = prometheus.NewGaugeVec(vlanOpts("svi_status", "SVI status: 1 if connected, 0 otherwise"), []string{"vlan", "interface"})
+	c.sviProtocolGauge = prometheus.NewGaugeVec(vlanOpts("svi_line_protocol", "SVI line protocol status: 1 if up, 0 otherwise"), []string{"vlan", "interface"})
+
+	registry.MustRegister(c.vlanInfo, c.activePorts, c.noActiveMembers, c.sviStatusGauge, c.sviProtocolGauge)
+}
+
+func (c *VlanCollector) UpdateMetrics() {
+	for id, vlan := range c.Vlans {
+		c.vlanInfo.WithLabelValues(id, vlan.Name, vlan.Status, strconv.FormatBool(vlan.Dynamic)).Set(1)
+
+		ports := 0
+		for ifName := range vlan.Interfaces {
+			// The CPU is listed as a member of every VLAN with an SVI
+			if ifName != "Cpu" {
+				ports++
+			}
+		}
+		c.activePorts.WithLabelValues(id).Set(float64(ports))
+
+		unused := 0.0
+		if ports == 0 {
+			unused = 1.0
+		}
+		c.noActiveMembers.WithLabelValues(id).Set(unused)
+	}
+
+	for ifName, iface := range c.ipInterfaces.Interfaces {
+		if !strings.HasPrefix(ifName, "Vlan") {
+			continue
+		}
+		vlan := strings.TrimPrefix(ifName, "Vlan")
+
+		status := 0.0
+		if iface.InterfaceStatus == "connected" {
+			status = 1.0
+		}
+		c.sviStatusGauge.WithLabelValues(vlan, ifName).Set(status)
+
+		protocol := 0.0
+		if iface.LineProtocolStatus == "up" {
+			protocol = 1.0
+		}
+		c.sviProtocolGauge.WithLabelValues(vlan, ifName).Set(protocol)
+	}
+}
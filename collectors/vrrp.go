@@ -0,0 +1,96 @@
+package collectors
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// VrrpCollector covers both first-hop redundancy flavours: VRRP groups and VARP virtual routers.
+type VrrpCollector struct {
+	VirtualRouters []struct {
+		Interface          string   `json:"interface"`
+		GroupID            int      `json:"groupId"`
+		VrfName            string   `json:"vrfName"`
+		State              string   `json:"state"`
+		Priority           int      `json:"priority"`
+		VirtualIp          string   `json:"virtualIp"`
+		VirtualIpSecondary []string `json:"virtualIpSecondary"`
+		MasterTransitions  int      `json:"masterTransitions"`
+	} `json:"virtualRouters"`
+
+	varp VirtualRouters
+
+	vrrpStateGauge       *prometheus.GaugeVec
+	vrrpPriorityGauge    *prometheus.GaugeVec
+	vrrpVirtualIpInfo    *prometheus.GaugeVec
+	vrrpTransitionsGauge *prometheus.GaugeVec
+	varpVirtualIpInfo    *prometheus.GaugeVec
+	varpProtocolStatus   *prometheus.GaugeVec
+}
+
+type VirtualRouters struct {
+	VirtualRouters []struct {
+		Interface      string   `json:"interface"`
+		Vrf            string   `json:"vrf"`
+		VirtualIps     []string `json:"virtualIps"`
+		ProtocolStatus string   `json:"protocolStatus"`
+	} `json:"virtualRouters"`
+}
+
+func (v *VirtualRouters) GetCmd() string {
+	return "show ip virtual-router"
+}
+
+func (c *VrrpCollector) GetCmd() string {
+	return "show vrrp"
+}
+
+func (c *VrrpCollector) ExtraCmds() []Command {
+	return []Command{&c.varp}
+}
+
+var vrrpOpts = MakeSubsystemOptsFactory("vrrp")
+var varpOpts = MakeSubsystemOptsFactory("varp")
+
+var vrrpStates = []string{"master", "backup", "init", "stopped"}
+
+func (c *VrrpCollector) Register(registry *prometheus.Registry) {
+	groupLabels := []string{"interface", "group", "vrf"}
+	c.vrrpStateGauge = prometheus.NewGaugeVec(vrrpOpts("state", "VRRP group state as a state set"), append(groupLabels, "state"))
+	c.vrrpPriorityGauge = prometheus.NewGaugeVec(vrrpOpts("priority", "VRRP priority of the local router"), groupLabels)
+	c.vrrpVirtualIpInfo = prometheus.NewGaugeVec(vrrpOpts("virtual_ip_info", "Virtual IP addresses of the VRRP group"), append(groupLabels, "virtual_ip"))
+	c.vrrpTransitionsGauge = prometheus.NewGaugeVec(vrrpOpts("master_transitions", "Number of transitions to master state"), groupLabels)
+
+	c.varpVirtualIpInfo = prometheus.NewGaugeVec(varpOpts("virtual_ip_info", "Virtual IP addresses of the VARP virtual router"), []string{"interface", "vrf", "virtual_ip"})
+	c.varpProtocolStatus = prometheus.NewGaugeVec(varpOpts("protocol_status", "VARP virtual router protocol status: 1 if up, 0 otherwise"), []string{"interface", "vrf"})
+
+	registry.MustRegister(c.vrrpStateGauge, c.vrrpPriorityGauge, c.vrrpVirtualIpInfo, c.vrrpTransitionsGauge)
+	registry.MustRegister(c.varpVirtualIpInfo, c.varpProtocolStatus)
+}
+
+func (c *VrrpCollector) UpdateMetrics() {
+	for _, vr := range c.VirtualRouters {
+		group := strconv.Itoa(vr.GroupID)
+		setStateSet(c.vrrpStateGauge, vrrpStates, strings.ToLower(vr.State), vr.Interface, group, vr.VrfName)
+		c.vrrpPriorityGauge.WithLabelValues(vr.Interface, group, vr.VrfName).Set(float64(vr.Priority))
+		c.vrrpTransitionsGauge.WithLabelValues(vr.Interface, group, vr.VrfName).Set(float64(vr.MasterTransitions))
+		for _, ip := range append([]string{vr.VirtualIp}, vr.VirtualIpSecondary...) {
+			if ip != "" {
+				c.vrrpVirtualIpInfo.WithLabelValues(vr.Interface, group, vr.VrfName, ip).Set(1)
+			}
+		}
+	}
+
+	for _, vr := range c.varp.VirtualRouters {
+		for _, ip := range vr.VirtualIps {
+			c.varpVirtualIpInfo.WithLabelValues(vr.Interface, vr.Vrf, ip).Set(1)
+		}
+		status := 0.0
+		if strings.EqualFold(vr.ProtocolStatus, "up") {
+			status = 1.0
+		}
+		c.varpProtocolStatus.WithLabelValues(vr.Interface, vr.Vrf).Set(status)
+	}
+}
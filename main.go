@@ -1,8 +1,10 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
 	"slices"
@@ -15,16 +17,31 @@ import (
 
 	"github.com/alecthomas/kingpin"
 	"github.com/aristanetworks/goeapi"
+	"github.com/modell-aachen/arista_exporter/collectors"
 )
 
 const (
 	metricsPath = "/metrics"
+
+	// Command limit of a goeapi request handle
+	maxCommandsPerCall = 64
 )
 
 var (
 	configFile        = kingpin.Flag("config.file", "Arista exporter config file").Default(".eapi.conf").String()
 	listenAddress     = kingpin.Flag("web.listen-address", "Address to listen on for web interface and telemetry.").Default(":9465").String()
-	enabledCollectors = kingpin.Flag("enabled-collectors", "Comma-separated list of collectors to enable. If empty, all are enabled.").Default("").String()
+	enabledCollectors = kingpin.Flag("enabled-collectors", "Comma-separated list of collectors to enable. If empty, all except the opt-in collectors ("+strings.Join(optInCollectors, ", ")+") are enabled.").Default("").String()
+	aclInclude        = kingpin.Flag("collector.acl.include", "Regexp of ACL and traffic policy names to export. If empty, all are exported.").Regexp()
+	aclExclude        = kingpin.Flag("collector.acl.exclude", "Regexp of ACL and traffic policy names not to export.").Regexp()
+	aclRuleText       = kingpin.Flag("collector.acl.rule-text", "Export the text of each ACL rule as an info metric.").Default("false").Bool()
+	aclTrafficPolicy  = kingpin.Flag("collector.acl.traffic-policy", "Export traffic policy counters, which are only supported on some platforms.").Default("false").Bool()
+	pimNeighborExpiry = kingpin.Flag("collector.pim.neighbor-expiry", "How long PIM neighbors that went down are still reported as down.").Default("24h").Duration()
+	macDetails        = kingpin.Flag("collector.mac.details", "Export MAC moves and ARP/IPv6 neighbor counts, which requires fetching the full tables.").Default("false").Bool()
+
+	// Collector state that is kept between scrapes
+	eventStates  = collectors.NewTargetStates[collectors.EventState]()
+	configStates = collectors.NewTargetStates[collectors.ConfigState]()
+	pimStates    = collectors.NewTargetStates[collectors.PimState]()
 )
 
 func handleMetricsRequest(w http.ResponseWriter, r *http.Request) {
@@ -51,31 +68,19 @@ func handleMetricsRequest(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	eapiHandle, err := node.GetHandle("json")
-	if err != nil {
-		log.Errorf("Failed to get handle for %q: %v", target, err)
-		http.Error(w, fmt.Sprintf("Failed to connect to %q", target), http.StatusInternalServerError)
-		return
-	}
-
 	// Collectors
-	collectorMap := getCollectorMap(*enabledCollectors)
+	collectorMap := getCollectorMap(*enabledCollectors, target)
 
 	// Specific metrics registry to handle this request
 	reg := prometheus.NewRegistry()
 
-	// Register prometheus metrics and eAPI commands
-	for name, coll := range collectorMap {
+	// Register prometheus metrics
+	for _, coll := range collectorMap {
 		coll.Register(reg)
-		if aErr := eapiHandle.AddCommand(coll); aErr != nil {
-			log.Fatalf("Failed to add command for collector %s", name)
-			http.Error(w, fmt.Sprintf("Failed to add command for collector %s", name), http.StatusInternalServerError)
-			return
-		}
 	}
 
 	// Execute commands
-	if cErr := eapiHandle.Call(); cErr != nil {
+	if cErr := runCollectors(node, target, collectorMap); cErr != nil {
 		http.Error(w, "Failed to run Arista eAPI Command", http.StatusInternalServerError)
 		log.Errorf("Failed to run Arista eAPI Command: %v", cErr)
 		return
@@ -91,6 +96,82 @@ func handleMetricsRequest(w http.ResponseWriter, r *http.Request) {
 	promhttp.HandlerFor(reg, promhttp.HandlerOpts{}).ServeHTTP(w, r)
 }
 
+// runCollectors runs the eAPI commands of all collectors. A single failing
+// command aborts the whole eAPI call, so if the switch rejects a command, every
+// collector is run on its own and those that still fail are removed from the
+// map. Only connection errors are returned.
+func runCollectors(node *goeapi.Node, target string, collectorMap map[string]Collector) error {
+	err := runCollectorCommands(node, collectorMap)
+	if err == nil || isConnectionError(err) {
+		return err
+	}
+	log.Warnf("Failed to run Arista eAPI Command, retrying by collector: %v", err)
+	for name, coll := range collectorMap {
+		if err := runCollectorCommands(node, map[string]Collector{name: coll}); err != nil {
+			if isConnectionError(err) {
+				return err
+			}
+			log.Errorf("Skipping collector %s for %q: %v", name, target, err)
+			delete(collectorMap, name)
+		}
+	}
+	return nil
+}
+
+// isConnectionError reports whether the eAPI call failed as a whole, rather
+// than because the switch rejected a command or its response didn't decode.
+// goeapi records both kinds as connection error, so they are told apart by type.
+func isConnectionError(err error) bool {
+	var urlErr *url.Error
+	return errors.As(err, &urlErr) || strings.HasPrefix(err.Error(), "Http error")
+}
+
+// runCollectorCommands runs the eAPI commands of the collectors and decodes the
+// responses into them.
+func runCollectorCommands(node *goeapi.Node, collectorMap map[string]Collector) error {
+	// Commands without a JSON representation are run separately with text encoding
+	var jsonCmds, textCmds []collectors.Command
+	for _, coll := range collectorMap {
+		if _, ok := coll.(collectors.TextOnlyCollector); ok {
+			textCmds = append(textCmds, coll)
+		} else {
+			jsonCmds = append(jsonCmds, coll)
+		}
+		if mc, ok := coll.(collectors.MultiCommandCollector); ok {
+			jsonCmds = append(jsonCmds, mc.ExtraCmds()...)
+		}
+		if tc, ok := coll.(collectors.TextCommandCollector); ok {
+			textCmds = append(textCmds, tc.TextCmds()...)
+		}
+	}
+
+	if err := runCommands(node, "json", jsonCmds); err != nil {
+		return err
+	}
+	return runCommands(node, "text", textCmds)
+}
+
+// runCommands executes the commands with the given encoding. A single eAPI
+// handle only takes maxCommandsPerCall commands, so they are split over as
+// many calls as needed.
+func runCommands(node *goeapi.Node, encoding string, cmds []collectors.Command) error {
+	for start := 0; start < len(cmds); start += maxCommandsPerCall {
+		handle, err := node.GetHandle(encoding)
+		if err != nil {
+			return fmt.Errorf("failed to get %s handle: %w", encoding, err)
+		}
+		for _, cmd := range cmds[start:min(start+maxCommandsPerCall, len(cmds))] {
+			if err := handle.AddCommand(cmd); err != nil {
+				return fmt.Errorf("failed to add command %q: %w", cmd.GetCmd(), err)
+			}
+		}
+		if err := handle.Call(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func startServer() {
 	log.Infof("Starting arista exporter (Version: %s)", version.Print("arista_exporter"))
 	http.HandleFunc("/", func(w http.ResponseWriter, _ *http.Request) {
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/aristanetworks/goeapi"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type testCollector struct {
+	Output string `json:"output"`
+
+	cmd string
+}
+
+func (c *testCollector) GetCmd() string                { return c.cmd }
+func (c *testCollector) Register(*prometheus.Registry) {}
+func (c *testCollector) UpdateMetrics()                {}
+
+// newTestEapi starts an eAPI server answering every command with its own name,
+// except the rejected one, which fails the whole call like EOS does.
+func newTestEapi(t *testing.T, rejected string) (*goeapi.Node, *httptest.Server) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     string `json:"id"`
+			Params struct {
+				Cmds []interface{} `json:"cmds"`
+			} `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("Failed to decode eAPI request: %v", err)
+			return
+		}
+
+		resp := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID}
+		var result []map[string]interface{}
+		for _, cmd := range req.Params.Cmds {
+			if cmd == rejected {
+				resp["error"] = map[string]interface{}{"code": 1002, "message": "CLI command 2 of 2 '" + rejected + "' failed: invalid command"}
+				result = nil
+				break
+			}
+			result = append(result, map[string]interface{}{"output": cmd})
+		}
+		if result != nil {
+			resp["result"] = result
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(server.Close)
+
+	host, portStr, _ := net.SplitHostPort(server.Listener.Addr().String())
+	port, _ := strconv.Atoi(portStr)
+	node, err := goeapi.Connect("http", host, "admin", "", port)
+	if err != nil {
+		t.Fatalf("Failed to connect to test eAPI: %v", err)
+	}
+	return node, server
+}
+
+func TestRunCollectorsSkipsRejectedCollector(t *testing.T) {
+	node, _ := newTestEapi(t, "show bad")
+	collectorMap := map[string]Collector{
+		"version": &testCollector{cmd: "show version"},
+		"bad":     &testCollector{cmd: "show bad"},
+		"bgp":     &testCollector{cmd: "show ip bgp summary"},
+	}
+
+	if err := runCollectors(node, "test", collectorMap); err != nil {
+		t.Fatalf("runCollectors returned %v, want nil", err)
+	}
+	if _, ok := collectorMap["bad"]; ok {
+		t.Errorf("Rejected collector was not skipped")
+	}
+	for _, name := range []string{"version", "bgp"} {
+		coll, ok := collectorMap[name].(*testCollector)
+		if !ok {
+			t.Errorf("Collector %s was skipped", name)
+			continue
+		}
+		if coll.Output != coll.cmd {
+			t.Errorf("Collector %s got output %q, want %q", name, coll.Output, coll.cmd)
+		}
+	}
+}
+
+func TestRunCollectorsFailsOnConnectionError(t *testing.T) {
+	node, server := newTestEapi(t, "")
+	server.Close()
+
+	collectorMap := map[string]Collector{
+		"version": &testCollector{cmd: "show version"},
+	}
+	if err := runCollectors(node, "test", collectorMap); err == nil {
+		t.Errorf("runCollectors returned nil on connection error")
+	}
+}